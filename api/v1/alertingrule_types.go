@@ -0,0 +1,129 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PrometheusDuration is a duration in the format of Prometheus, e.g. 30s, 5m or 1h30m.
+// +kubebuilder:validation:Pattern:="((([0-9]+)y)?(([0-9]+)w)?(([0-9]+)d)?(([0-9]+)h)?(([0-9]+)m)?(([0-9]+)s)?(([0-9]+)ms)?|0)"
+type PrometheusDuration string
+
+// AlertingRuleSpec defines the LogQL alerting rules of a tenant.
+type AlertingRuleSpec struct {
+	// TenantID is the tenant the rules are evaluated for. Stacks without a
+	// gateway run with a single tenant named "fake".
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength=150
+	// +kubebuilder:validation:Pattern:="^[a-zA-Z0-9!_*'()-][a-zA-Z0-9!._*'()-]*$"
+	TenantID string `json:"tenantID"`
+
+	// Groups is the list of alerting rule groups.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	Groups []*AlertingRuleGroup `json:"groups"`
+}
+
+// AlertingRuleGroup is a named group of alerting rules evaluated at the same interval.
+type AlertingRuleGroup struct {
+	// Name of the group. It must be unique within the AlertingRule.
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Interval is how often the rules of the group are evaluated.
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:="1m"
+	Interval PrometheusDuration `json:"interval,omitempty"`
+
+	// Limit is the number of alerts a rule of the group may produce. 0 is no limit.
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	Limit int32 `json:"limit,omitempty"`
+
+	// Rules is the list of alerting rules of the group.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	Rules []*AlertingRuleGroupSpec `json:"rules"`
+}
+
+// AlertingRuleGroupSpec defines a single LogQL alerting rule.
+type AlertingRuleGroupSpec struct {
+	// Alert is the name of the alert.
+	// +kubebuilder:validation:Required
+	Alert string `json:"alert"`
+
+	// Expr is the LogQL expression to evaluate.
+	// +kubebuilder:validation:Required
+	Expr string `json:"expr"`
+
+	// For is how long the expression has to be true before the alert fires.
+	// +optional
+	// +kubebuilder:validation:Optional
+	For PrometheusDuration `json:"for,omitempty"`
+
+	// Annotations are added to the alert.
+	// +optional
+	// +kubebuilder:validation:Optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// Labels are added to or overwritten on the alert.
+	// +optional
+	// +kubebuilder:validation:Optional
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// AlertingRuleStatus defines the observed state of AlertingRule
+type AlertingRuleStatus struct {
+	// +optional
+	// +kubebuilder:validation:Optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Tenant",type="string",JSONPath=".spec.tenantID"
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// AlertingRule is the Schema for the alertingrules API. The rules are loaded by
+// the ruler of every SsdLoki whose rules selector matches it.
+type AlertingRule struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// +kubebuilder:validation:Required
+	Spec AlertingRuleSpec `json:"spec"`
+
+	// +optional
+	// +kubebuilder:validation:Optional
+	Status AlertingRuleStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// AlertingRuleList contains a list of AlertingRule
+type AlertingRuleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AlertingRule `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&AlertingRule{}, &AlertingRuleList{})
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// LokiDeleteRequestSpec defines the logs deleted from a stack.
+// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="spec is immutable"
+// +kubebuilder:validation:XValidation:rule="timestamp(self.end) > timestamp(self.start)",message="end must be after start"
+type LokiDeleteRequestSpec struct {
+	// StackName is the name of the SsdLoki in the namespace of the request whose
+	// compactor deletes the logs.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	StackName string `json:"stackName"`
+
+	// TenantID is the tenant the logs are deleted from. Stacks without a gateway
+	// run with a single tenant named "fake".
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength=150
+	// +kubebuilder:validation:Pattern:="^[a-zA-Z0-9!_*'()-][a-zA-Z0-9!._*'()-]*$"
+	TenantID string `json:"tenantID"`
+
+	// Query is the LogQL stream selector of the deleted logs, optionally followed
+	// by line filters, e.g. {app="checkout"} |= "card_number".
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Query string `json:"query"`
+
+	// Start is the beginning of the time range of the deleted logs.
+	// +kubebuilder:validation:Required
+	Start metav1.Time `json:"start"`
+
+	// End is the end of the time range of the deleted logs.
+	// +kubebuilder:validation:Required
+	End metav1.Time `json:"end"`
+}
+
+// LokiDeleteRequestPhase is the progress of a delete request.
+type LokiDeleteRequestPhase string
+
+const (
+	// DeleteRequestPending when the request has not been accepted by the compactor yet.
+	DeleteRequestPending LokiDeleteRequestPhase = "Pending"
+	// DeleteRequestReceived when the compactor accepted the request. It is
+	// processed once the cancellation grace period of the compactor has passed.
+	DeleteRequestReceived LokiDeleteRequestPhase = "Received"
+	// DeleteRequestProcessed when the compactor deleted the logs.
+	DeleteRequestProcessed LokiDeleteRequestPhase = "Processed"
+	// DeleteRequestFailed when the request cannot be submitted to the stack.
+	DeleteRequestFailed LokiDeleteRequestPhase = "Failed"
+)
+
+// LokiDeleteRequestStatus defines the observed state of LokiDeleteRequest
+type LokiDeleteRequestStatus struct {
+	// Phase is the progress of the request.
+	// +optional
+	// +kubebuilder:validation:Optional
+	Phase LokiDeleteRequestPhase `json:"phase,omitempty"`
+
+	// RequestID is the ID the compactor assigned to the request.
+	// +optional
+	// +kubebuilder:validation:Optional
+	RequestID string `json:"requestID,omitempty"`
+
+	// Message explains the phase.
+	// +optional
+	// +kubebuilder:validation:Optional
+	Message string `json:"message,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Stack",type="string",JSONPath=".spec.stackName"
+//+kubebuilder:printcolumn:name="Tenant",type="string",JSONPath=".spec.tenantID"
+//+kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// LokiDeleteRequest is the Schema for the lokideleterequests API. It deletes the
+// matching logs through the compactor of a SsdLoki. Deleting the object while
+// the request is still Received cancels it.
+type LokiDeleteRequest struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// +kubebuilder:validation:Required
+	Spec LokiDeleteRequestSpec `json:"spec"`
+
+	// +optional
+	// +kubebuilder:validation:Optional
+	Status LokiDeleteRequestStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// LokiDeleteRequestList contains a list of LokiDeleteRequest
+type LokiDeleteRequestList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []LokiDeleteRequest `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&LokiDeleteRequest{}, &LokiDeleteRequestList{})
+}
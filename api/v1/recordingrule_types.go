@@ -0,0 +1,117 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RecordingRuleSpec defines the LogQL recording rules of a tenant.
+type RecordingRuleSpec struct {
+	// TenantID is the tenant the rules are evaluated for. Stacks without a
+	// gateway run with a single tenant named "fake".
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength=150
+	// +kubebuilder:validation:Pattern:="^[a-zA-Z0-9!_*'()-][a-zA-Z0-9!._*'()-]*$"
+	TenantID string `json:"tenantID"`
+
+	// Groups is the list of recording rule groups.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	Groups []*RecordingRuleGroup `json:"groups"`
+}
+
+// RecordingRuleGroup is a named group of recording rules evaluated at the same interval.
+type RecordingRuleGroup struct {
+	// Name of the group. It must be unique within the RecordingRule.
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Interval is how often the rules of the group are evaluated.
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:="1m"
+	Interval PrometheusDuration `json:"interval,omitempty"`
+
+	// Limit is the number of series a rule of the group may produce. 0 is no limit.
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	Limit int32 `json:"limit,omitempty"`
+
+	// Rules is the list of recording rules of the group.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	Rules []*RecordingRuleGroupSpec `json:"rules"`
+}
+
+// RecordingRuleGroupSpec defines a single LogQL recording rule.
+type RecordingRuleGroupSpec struct {
+	// Record is the name of the time series the result is written to. It must
+	// be a valid metric name.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern:="^[a-zA-Z_:][a-zA-Z0-9_:]*$"
+	Record string `json:"record"`
+
+	// Expr is the LogQL metric expression to evaluate.
+	// +kubebuilder:validation:Required
+	Expr string `json:"expr"`
+
+	// Labels are added to or overwritten on the recorded series.
+	// +optional
+	// +kubebuilder:validation:Optional
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// RecordingRuleStatus defines the observed state of RecordingRule
+type RecordingRuleStatus struct {
+	// +optional
+	// +kubebuilder:validation:Optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Tenant",type="string",JSONPath=".spec.tenantID"
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// RecordingRule is the Schema for the recordingrules API. The rules are loaded
+// by the ruler of every SsdLoki whose rules selector matches it.
+type RecordingRule struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// +kubebuilder:validation:Required
+	Spec RecordingRuleSpec `json:"spec"`
+
+	// +optional
+	// +kubebuilder:validation:Optional
+	Status RecordingRuleStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// RecordingRuleList contains a list of RecordingRule
+type RecordingRuleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RecordingRule `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&RecordingRule{}, &RecordingRuleList{})
+}
@@ -12,13 +12,16 @@ You may obtain a copy of the License at
 package v1
 
 import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // BloomBuild 설정 구조체
 type BloomBuild struct {
-	// +kubebuilder:validation:Required
-	Enabled bool `json:"enabled"`
+	// +optional
+	// +kubebuilder:validation:Optional
+	Enabled bool `json:"enabled,omitempty"`
 
 	// +optional
 	// +kubebuilder:validation:Optional
@@ -26,362 +29,1480 @@ type BloomBuild struct {
 }
 
 type BloomBuildBuilder struct {
-	// +kubebuilder:validation:Required
-	PlannerAddress string `json:"plannerAddress"`
+	// +optional
+	// +kubebuilder:validation:Optional
+	PlannerAddress string `json:"plannerAddress,omitempty"`
 }
 
 // BloomGateway 설정 구조체
 type BloomGateway struct {
+	// +optional
+	// +kubebuilder:validation:Optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Optional
+	Client *BloomGatewayClient `json:"client,omitempty"`
+}
+
+type BloomGatewayClient struct {
+	// +optional
+	// +kubebuilder:validation:Optional
+	Addresses string `json:"addresses,omitempty"`
+}
+
+// ChunkStoreConfig 설정 구조체
+type ChunkStoreConfig struct {
+	// +optional
+	// +kubebuilder:validation:Optional
+	ChunkCacheConfig *ChunkCacheConfig `json:"chunkCacheConfig,omitempty"`
+}
+
+type ChunkCacheConfig struct {
+	// +optional
+	// +kubebuilder:validation:Optional
+	Background *CacheBackgroundConfig `json:"background,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Optional
+	DefaultValidity string `json:"defaultValidity,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Optional
+	Memcached *MemcachedConfig `json:"memcached,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Optional
+	MemcachedClient *MemcachedClientConfig `json:"memcachedClient,omitempty"`
+
+	// Mode selects whether the operator deploys memcached for the chunks cache or
+	// Loki connects to the addresses set in memcachedClient.
+	// +optional
+	// +kubebuilder:validation:Optional
+	Mode CacheMode `json:"mode,omitempty"`
+
+	// Managed defines the memcached deployment of the chunks cache in managed mode.
+	// +optional
+	// +kubebuilder:validation:Optional
+	Managed *ManagedCacheSpec `json:"managed,omitempty"`
+}
+
+// CacheMode defines who runs the memcached servers of a cache. The cache is
+// disabled when no mode is set and no memcached addresses are configured.
+//
+// +kubebuilder:validation:Enum=managed;external
+type CacheMode string
+
+const (
+	// CacheModeManaged deploys memcached with the stack and points Loki at it.
+	CacheModeManaged CacheMode = "managed"
+
+	// CacheModeExternal points Loki at the memcached addresses set in memcachedClient.
+	CacheModeExternal CacheMode = "external"
+)
+
+// ManagedCacheSpec defines a memcached StatefulSet deployed by the operator.
+type ManagedCacheSpec struct {
+	// Replicas is the number of memcached pods. Defaults to 1.
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// MemoryLimitMB is the memory in megabytes memcached uses for items.
+	// Defaults to 8192 for the chunks cache and 1024 for the results cache.
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=64
+	MemoryLimitMB int32 `json:"memoryLimitMB,omitempty"`
+
+	// MaxItemSize is the largest item memcached stores, e.g. 5m. Defaults to 5m.
+	// +optional
+	// +kubebuilder:validation:Optional
+	MaxItemSize string `json:"maxItemSize,omitempty"`
+
+	// ConnectionLimit is the maximum number of client connections. Defaults to 16384.
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	ConnectionLimit int32 `json:"connectionLimit,omitempty"`
+
+	// Resources are the requests and limits of the memcached container. They
+	// default to the memory limit plus 20% overhead.
+	// +optional
+	// +kubebuilder:validation:Optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+}
+
+type CacheBackgroundConfig struct {
+	// +optional
+	// +kubebuilder:validation:Optional
+	WritebackBuffer int `json:"writebackBuffer,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Optional
+	WritebackGoroutines int `json:"writebackGoroutines,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Optional
+	WritebackSizeLimit string `json:"writebackSizeLimit,omitempty"`
+}
+
+type MemcachedConfig struct {
+	// +optional
+	// +kubebuilder:validation:Optional
+	BatchSize int `json:"batchSize,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Optional
+	Parallelism int `json:"parallelism,omitempty"`
+}
+
+type MemcachedClientConfig struct {
+	// +optional
+	// +kubebuilder:validation:Optional
+	Addresses string `json:"addresses,omitempty"`
+
+	// ConsistentHash defaults to true.
+	// +optional
+	// +kubebuilder:validation:Optional
+	ConsistentHash *bool `json:"consistentHash,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Optional
+	MaxIdleConns int `json:"maxIdleConns,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Optional
+	Timeout string `json:"timeout,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Optional
+	UpdateInterval string `json:"updateInterval,omitempty"`
+}
+
+// Common 설정 구조체
+type CommonConfig struct {
+	// +optional
+	// +kubebuilder:validation:Optional
+	CompactorAddress string `json:"compactorAddress,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Optional
+	PathPrefix string `json:"pathPrefix,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Optional
+	ReplicationFactor int `json:"replicationFactor,omitempty"`
+
+	// Storage configures the object storage shared by all components. When it is
+	// omitted, chunks and index are written to the local filesystem of each pod,
+	// which is only suitable for testing.
+	// +optional
+	// +kubebuilder:validation:Optional
+	Storage *CommonStorage `json:"storage,omitempty"`
+}
+
+// CommonStorage selects the object storage backend. At most one backend may be
+// set; the filesystem of each pod is used when none is.
+type CommonStorage struct {
+	// Secret references the Secret holding the object storage credentials.
+	// +optional
+	// +kubebuilder:validation:Optional
+	Secret *ObjectStorageSecretSpec `json:"secret,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Optional
+	S3 *S3Config `json:"s3,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Optional
+	GCS *GCSConfig `json:"gcs,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Optional
+	Azure *AzureConfig `json:"azure,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Optional
+	Swift *SwiftConfig `json:"swift,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Optional
+	Filesystem *FilesystemConfig `json:"filesystem,omitempty"`
+}
+
+// ObjectStorageSecretSpec references a Secret in the namespace of the SsdLoki.
+//
+// The keys the Secret must contain depend on the backend:
+//
+//   - s3: access_key_id and access_key_secret
+//   - gcs: key.json with the service account credentials
+//   - azure: account_name and account_key
+//   - swift: username and password
+//
+// The secret is optional for s3 and gcs and required for azure and swift.
+// Changes to the Secret roll out all tiers.
+type ObjectStorageSecretSpec struct {
+	// Name of the Secret.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+}
+
+type S3Config struct {
+	// +kubebuilder:validation:Required
+	BucketNames string `json:"bucketnames"`
+
+	// +kubebuilder:validation:Required
+	Endpoint string `json:"endpoint"`
+
+	// +optional
+	// +kubebuilder:validation:Optional
+	Insecure bool `json:"insecure,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Optional
+	S3ForcePathStyle bool `json:"s3ForcePathStyle,omitempty"`
+}
+
+// GCSConfig configures Google Cloud Storage.
+type GCSConfig struct {
+	// +kubebuilder:validation:Required
+	BucketName string `json:"bucketName"`
+}
+
+// AzureConfig configures Azure Blob Storage.
+type AzureConfig struct {
+	// +kubebuilder:validation:Required
+	ContainerName string `json:"containerName"`
+
+	// EndpointSuffix overrides the default blob.core.windows.net, e.g. for sovereign clouds.
+	// +optional
+	// +kubebuilder:validation:Optional
+	EndpointSuffix string `json:"endpointSuffix,omitempty"`
+}
+
+// SwiftConfig configures OpenStack Swift.
+type SwiftConfig struct {
+	// +kubebuilder:validation:Required
+	AuthURL string `json:"authUrl"`
+
+	// +kubebuilder:validation:Required
+	ContainerName string `json:"containerName"`
+
+	// +optional
+	// +kubebuilder:validation:Optional
+	UserDomainName string `json:"userDomainName,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Optional
+	ProjectName string `json:"projectName,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Optional
+	ProjectDomainName string `json:"projectDomainName,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Optional
+	RegionName string `json:"regionName,omitempty"`
+}
+
+// FilesystemConfig stores chunks and rules on the data volume of each pod.
+// It is only suitable for testing.
+type FilesystemConfig struct {
+	// ChunksDirectory defaults to the chunks directory below the path prefix.
+	// +optional
+	// +kubebuilder:validation:Optional
+	ChunksDirectory string `json:"chunksDirectory,omitempty"`
+
+	// RulesDirectory defaults to the rules directory below the path prefix.
+	// +optional
+	// +kubebuilder:validation:Optional
+	RulesDirectory string `json:"rulesDirectory,omitempty"`
+}
+
+// Frontend 설정 구조체
+type FrontendConfig struct {
+	// +kubebuilder:validation:Required
+	SchedulerAddress string `json:"schedulerAddress"`
+
+	// +kubebuilder:validation:Required
+	TailProxyURL string `json:"tailProxyUrl"`
+}
+
+type FrontendWorkerConfig struct {
 	// +kubebuilder:validation:Required
-	Enabled bool `json:"enabled"`
+	SchedulerAddress string `json:"schedulerAddress"`
+}
+
+// IndexGateway 설정 구조체
+type IndexGatewayConfig struct {
+	// +optional
+	// +kubebuilder:validation:Optional
+	Mode string `json:"mode,omitempty"`
+}
+
+// Ingester 설정 구조체
+type IngesterConfig struct {
+	// +optional
+	// +kubebuilder:validation:Optional
+	ChunkEncoding string `json:"chunkEncoding,omitempty"`
+}
+
+// LimitsConfig 설정 구조체
+type LimitsConfig struct {
+	// +optional
+	// +kubebuilder:validation:Optional
+	MaxCacheFreshnessPerQuery string `json:"maxCacheFreshnessPerQuery,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Optional
+	QueryTimeout string `json:"queryTimeout,omitempty"`
+
+	// RejectOldSamples defaults to true.
+	// +optional
+	// +kubebuilder:validation:Optional
+	RejectOldSamples *bool `json:"rejectOldSamples,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Optional
+	RejectOldSamplesMaxAge string `json:"rejectOldSamplesMaxAge,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Optional
+	SplitQueriesByInterval string `json:"splitQueriesByInterval,omitempty"`
+
+	// VolumeEnabled defaults to true.
+	// +optional
+	// +kubebuilder:validation:Optional
+	VolumeEnabled *bool `json:"volumeEnabled,omitempty"`
+}
+
+// PerTenantLimitsConfig defines the limits overridden for a single tenant.
+// Fields left unset fall back to the global LimitsConfig.
+type PerTenantLimitsConfig struct {
+	// +optional
+	// +kubebuilder:validation:Optional
+	IngestionRateMB int `json:"ingestionRateMB,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Optional
+	IngestionBurstSizeMB int `json:"ingestionBurstSizeMB,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Optional
+	MaxGlobalStreamsPerUser int `json:"maxGlobalStreamsPerUser,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Optional
+	MaxLineSize string `json:"maxLineSize,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Optional
+	PerStreamRateLimit string `json:"perStreamRateLimit,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Optional
+	PerStreamRateLimitBurst string `json:"perStreamRateLimitBurst,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Optional
+	MaxEntriesLimitPerQuery int `json:"maxEntriesLimitPerQuery,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Optional
+	MaxQuerySeries int `json:"maxQuerySeries,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Optional
+	MaxQueryLength string `json:"maxQueryLength,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Optional
+	MaxCacheFreshnessPerQuery string `json:"maxCacheFreshnessPerQuery,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Optional
+	QueryTimeout string `json:"queryTimeout,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Optional
+	RejectOldSamples *bool `json:"rejectOldSamples,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Optional
+	RejectOldSamplesMaxAge string `json:"rejectOldSamplesMaxAge,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Optional
+	SplitQueriesByInterval string `json:"splitQueriesByInterval,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Optional
+	VolumeEnabled *bool `json:"volumeEnabled,omitempty"`
+
+	// Retention overrides the retention of the stack for the tenant.
+	// +optional
+	// +kubebuilder:validation:Optional
+	Retention *RetentionLimitSpec `json:"retention,omitempty"`
+}
+
+// Memberlist 설정 구조체
+type MemberlistConfig struct {
+	// +optional
+	// +kubebuilder:validation:Optional
+	JoinMembers []string `json:"joinMembers,omitempty"`
+}
+
+// PatternIngester 설정 구조체
+type PatternIngesterConfig struct {
+	// +optional
+	// +kubebuilder:validation:Optional
+	Enabled bool `json:"enabled,omitempty"`
+}
+
+// Querier 설정 구조체
+type QuerierConfig struct {
+	// +optional
+	// +kubebuilder:validation:Optional
+	MaxConcurrent int `json:"maxConcurrent,omitempty"`
+}
+
+// QueryRange 설정 구조체
+type QueryRangeConfig struct {
+	// AlignQueriesWithStep defaults to true.
+	// +optional
+	// +kubebuilder:validation:Optional
+	AlignQueriesWithStep *bool `json:"alignQueriesWithStep,omitempty"`
+
+	// CacheResults defaults to true.
+	// +optional
+	// +kubebuilder:validation:Optional
+	CacheResults *bool `json:"cacheResults,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Optional
+	ResultsCache *ResultsCacheConfig `json:"resultsCache,omitempty"`
+}
+
+type ResultsCacheConfig struct {
+	// +optional
+	// +kubebuilder:validation:Optional
+	Cache *CacheConfig `json:"cache,omitempty"`
+}
+
+type CacheConfig struct {
+	// +optional
+	// +kubebuilder:validation:Optional
+	Background *CacheBackgroundConfig `json:"background,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Optional
+	DefaultValidity string `json:"defaultValidity,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Optional
+	MemcachedClient *MemcachedClientConfig `json:"memcachedClient,omitempty"`
+
+	// Mode selects whether the operator deploys memcached for the results cache or
+	// Loki connects to the addresses set in memcachedClient.
+	// +optional
+	// +kubebuilder:validation:Optional
+	Mode CacheMode `json:"mode,omitempty"`
+
+	// Managed defines the memcached deployment of the results cache in managed mode.
+	// +optional
+	// +kubebuilder:validation:Optional
+	Managed *ManagedCacheSpec `json:"managed,omitempty"`
+}
+
+// Ruler 설정 구조체
+type RulerConfig struct {
+	// +optional
+	// +kubebuilder:validation:Optional
+	Storage *RulerStorageConfig `json:"storage,omitempty"`
+
+	// AlertManager defines the Alertmanagers the ruler sends alerts to.
+	// +optional
+	// +kubebuilder:validation:Optional
+	AlertManager *AlertManagerSpec `json:"alertmanager,omitempty"`
+
+	// Overrides defines per-tenant Alertmanager settings keyed by tenant ID. They
+	// are rendered into the runtime config.
+	// +optional
+	// +kubebuilder:validation:Optional
+	Overrides map[string]RulerOverridesSpec `json:"overrides,omitempty"`
+
+	// RemoteWrite sends the results of recording rules to a Prometheus-compatible endpoint.
+	// +optional
+	// +kubebuilder:validation:Optional
+	RemoteWrite *RemoteWriteSpec `json:"remoteWrite,omitempty"`
+}
+
+// RemoteWriteSpec defines the remote write of the results of recording rules.
+// The write-ahead log of the samples is kept on the volume of the backend tier.
+type RemoteWriteSpec struct {
+	// Enabled sends the results of recording rules to the client.
+	// +optional
+	// +kubebuilder:validation:Optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// RefreshPeriod is how often the remote write config of the tenants is reloaded.
+	// +optional
+	// +kubebuilder:validation:Optional
+	RefreshPeriod PrometheusDuration `json:"refreshPeriod,omitempty"`
+
+	// Client defines the endpoint the samples are written to.
+	// +kubebuilder:validation:Required
+	Client RemoteWriteClientSpec `json:"client"`
+
+	// Queue tunes the queue of samples waiting to be sent.
+	// +optional
+	// +kubebuilder:validation:Optional
+	Queue *RemoteWriteQueueSpec `json:"queue,omitempty"`
+
+	// RelabelConfigs are applied to the samples before they are sent.
+	// +optional
+	// +kubebuilder:validation:Optional
+	RelabelConfigs []RelabelConfigSpec `json:"relabelConfigs,omitempty"`
+}
+
+// RemoteWriteAuthType is the authentication of the remote write client.
+//
+// +kubebuilder:validation:Enum=basic;bearer
+type RemoteWriteAuthType string
+
+const (
+	// RemoteWriteAuthBasic authenticates with the username and password keys of the Secret.
+	RemoteWriteAuthBasic RemoteWriteAuthType = "basic"
+	// RemoteWriteAuthBearer authenticates with the bearer_token key of the Secret.
+	RemoteWriteAuthBearer RemoteWriteAuthType = "bearer"
+)
+
+// RemoteWriteClientSpec defines the endpoint of the remote write.
+type RemoteWriteClientSpec struct {
+	// Name identifies the client in the metrics of the ruler.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// URL of the remote write endpoint, e.g. http://prometheus:9090/api/v1/write.
+	// +kubebuilder:validation:Required
+	URL string `json:"url"`
+
+	// Timeout of a request to the endpoint.
+	// +optional
+	// +kubebuilder:validation:Optional
+	Timeout PrometheusDuration `json:"timeout,omitempty"`
+
+	// Headers are added to every request.
+	// +optional
+	// +kubebuilder:validation:Optional
+	Headers map[string]string `json:"headers,omitempty"`
+
+	// ProxyURL is the URL of an HTTP proxy.
+	// +optional
+	// +kubebuilder:validation:Optional
+	ProxyURL string `json:"proxyUrl,omitempty"`
+
+	// FollowRedirects follows HTTP 3xx redirects.
+	// +optional
+	// +kubebuilder:validation:Optional
+	FollowRedirects bool `json:"followRedirects,omitempty"`
+
+	// AuthorizationType selects the authentication with the credentials of the Secret.
+	// +optional
+	// +kubebuilder:validation:Optional
+	AuthorizationType RemoteWriteAuthType `json:"authorization,omitempty"`
+
+	// AuthorizationSecretName is the name of the Secret in the namespace of the
+	// stack holding username and password, or bearer_token.
+	// +optional
+	// +kubebuilder:validation:Optional
+	AuthorizationSecretName string `json:"authorizationSecretName,omitempty"`
+}
+
+// RemoteWriteQueueSpec tunes the queue of the remote write client.
+type RemoteWriteQueueSpec struct {
+	// Capacity is the number of samples buffered per shard.
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	Capacity int32 `json:"capacity,omitempty"`
+
+	// MaxShards is the maximum number of concurrent senders.
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	MaxShards int32 `json:"maxShards,omitempty"`
+
+	// MinShards is the minimum number of concurrent senders.
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	MinShards int32 `json:"minShards,omitempty"`
+
+	// MaxSamplesPerSend is the maximum number of samples per request.
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	MaxSamplesPerSend int32 `json:"maxSamplesPerSend,omitempty"`
+
+	// BatchSendDeadline is how long a sample waits in the buffer before it is sent.
+	// +optional
+	// +kubebuilder:validation:Optional
+	BatchSendDeadline PrometheusDuration `json:"batchSendDeadline,omitempty"`
+
+	// MinBackOffPeriod is the initial retry delay, doubled on every retry.
+	// +optional
+	// +kubebuilder:validation:Optional
+	MinBackOffPeriod PrometheusDuration `json:"minBackOffPeriod,omitempty"`
+
+	// MaxBackOffPeriod is the maximum retry delay.
+	// +optional
+	// +kubebuilder:validation:Optional
+	MaxBackOffPeriod PrometheusDuration `json:"maxBackOffPeriod,omitempty"`
+}
+
+// RulerOverridesSpec defines the ruler settings of a tenant.
+type RulerOverridesSpec struct {
+	// AlertManager replaces the Alertmanager settings of the stack for the tenant.
+	// +optional
+	// +kubebuilder:validation:Optional
+	AlertManager *AlertManagerOverrideSpec `json:"alertmanager,omitempty"`
+}
+
+// AlertManagerSpec defines the Alertmanagers of the ruler and how alerts are sent.
+type AlertManagerSpec struct {
+	AlertManagerOverrideSpec `json:",inline"`
+
+	// ExternalURL is the URL under which alerts link back to the ruler.
+	// +optional
+	// +kubebuilder:validation:Optional
+	ExternalURL string `json:"externalUrl,omitempty"`
+
+	// ExternalLabels are added to all alerts.
+	// +optional
+	// +kubebuilder:validation:Optional
+	ExternalLabels map[string]string `json:"externalLabels,omitempty"`
+
+	// ForOutageTolerance is the longest ruler outage after which the for state
+	// of alerts is restored.
+	// +optional
+	// +kubebuilder:validation:Optional
+	ForOutageTolerance PrometheusDuration `json:"forOutageTolerance,omitempty"`
+
+	// ForGracePeriod is the minimum duration between an alert and its restored
+	// for state, for alerts with a for duration above it.
+	// +optional
+	// +kubebuilder:validation:Optional
+	ForGracePeriod PrometheusDuration `json:"forGracePeriod,omitempty"`
+
+	// ResendDelay is the minimum duration before a firing alert is sent again.
+	// +optional
+	// +kubebuilder:validation:Optional
+	ResendDelay PrometheusDuration `json:"resendDelay,omitempty"`
+}
+
+// AlertManagerOverrideSpec defines the Alertmanager settings that can be set per tenant.
+type AlertManagerOverrideSpec struct {
+	// Endpoints are the URLs of the Alertmanagers. With discovery enabled, the
+	// hosts are resolved via DNS SRV records.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	Endpoints []string `json:"endpoints"`
+
+	// Discovery resolves the endpoints via DNS.
+	// +optional
+	// +kubebuilder:validation:Optional
+	Discovery *AlertManagerDiscoverySpec `json:"discovery,omitempty"`
+
+	// NotificationQueue defines the queue of alerts waiting to be sent.
+	// +optional
+	// +kubebuilder:validation:Optional
+	NotificationQueue *AlertManagerNotificationQueueSpec `json:"notificationQueue,omitempty"`
+
+	// RelabelConfigs are applied to alerts before they are sent.
+	// +optional
+	// +kubebuilder:validation:Optional
+	RelabelConfigs []RelabelConfigSpec `json:"relabelConfigs,omitempty"`
+
+	// Client defines TLS and authentication of the requests to the Alertmanagers.
+	// +optional
+	// +kubebuilder:validation:Optional
+	Client *AlertManagerClientSpec `json:"client,omitempty"`
+}
+
+// AlertManagerDiscoverySpec defines the DNS discovery of the Alertmanagers.
+type AlertManagerDiscoverySpec struct {
+	// EnableSRV resolves the hosts of the endpoints via DNS SRV records.
+	// +optional
+	// +kubebuilder:validation:Optional
+	EnableSRV bool `json:"enableSRV,omitempty"`
+
+	// RefreshInterval is how often the endpoints are resolved.
+	// +optional
+	// +kubebuilder:validation:Optional
+	RefreshInterval PrometheusDuration `json:"refreshInterval,omitempty"`
+}
+
+// AlertManagerNotificationQueueSpec defines the queue of alerts waiting to be sent.
+type AlertManagerNotificationQueueSpec struct {
+	// Capacity is the number of alerts that can be queued.
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	Capacity int32 `json:"capacity,omitempty"`
+
+	// Timeout is the HTTP timeout of sending alerts to an Alertmanager.
+	// +optional
+	// +kubebuilder:validation:Optional
+	Timeout PrometheusDuration `json:"timeout,omitempty"`
+}
+
+// RelabelActionType is the action of a relabel config.
+//
+// +kubebuilder:validation:Enum=drop;hashmod;keep;labeldrop;labelkeep;labelmap;replace
+type RelabelActionType string
+
+// RelabelConfigSpec rewrites the labels of alerts or samples, see the relabel_config of Prometheus.
+type RelabelConfigSpec struct {
+	// SourceLabels select values from existing labels.
+	// +kubebuilder:validation:Required
+	SourceLabels []string `json:"sourceLabels"`
+
+	// Separator is placed between concatenated source label values. Defaults to ;.
+	// +optional
+	// +kubebuilder:validation:Optional
+	Separator string `json:"separator,omitempty"`
+
+	// TargetLabel is the label the result is written to in a replace action.
+	// +optional
+	// +kubebuilder:validation:Optional
+	TargetLabel string `json:"targetLabel,omitempty"`
+
+	// Regex is matched against the concatenated source label values.
+	// +optional
+	// +kubebuilder:validation:Optional
+	Regex string `json:"regex,omitempty"`
+
+	// Modulus is taken of the hash of the source label values.
+	// +optional
+	// +kubebuilder:validation:Optional
+	Modulus uint64 `json:"modulus,omitempty"`
+
+	// Replacement is the value written in a replace action.
+	// +optional
+	// +kubebuilder:validation:Optional
+	Replacement string `json:"replacement,omitempty"`
+
+	// Action is performed on a regex match. Defaults to replace.
+	// +optional
+	// +kubebuilder:validation:Optional
+	Action RelabelActionType `json:"action,omitempty"`
+}
+
+// AlertManagerClientSpec defines TLS and authentication of the requests to the
+// Alertmanagers. Referenced Secrets and ConfigMaps must be in the namespace of
+// the stack.
+type AlertManagerClientSpec struct {
+	// TLS defines the TLS settings of the client.
+	// +optional
+	// +kubebuilder:validation:Optional
+	TLS *AlertManagerClientTLSSpec `json:"tls,omitempty"`
+
+	// BasicAuth authenticates with a username and password.
+	// +optional
+	// +kubebuilder:validation:Optional
+	BasicAuth *AlertManagerClientBasicAuthSpec `json:"basicAuth,omitempty"`
+
+	// HeaderAuth authenticates with credentials in the Authorization header.
+	// +optional
+	// +kubebuilder:validation:Optional
+	HeaderAuth *AlertManagerClientHeaderAuthSpec `json:"headerAuth,omitempty"`
+}
+
+// AlertManagerClientTLSSpec defines the TLS settings of the Alertmanager client.
+type AlertManagerClientTLSSpec struct {
+	// CA is the CA bundle the Alertmanager certificates are verified with.
+	// +optional
+	// +kubebuilder:validation:Optional
+	CA *CASpec `json:"ca,omitempty"`
+
+	// CertSecretName is the name of a Secret of type kubernetes.io/tls holding
+	// the client certificate.
+	// +optional
+	// +kubebuilder:validation:Optional
+	CertSecretName string `json:"certSecretName,omitempty"`
+
+	// ServerName is the name the Alertmanager certificates are verified against.
+	// +optional
+	// +kubebuilder:validation:Optional
+	ServerName string `json:"serverName,omitempty"`
+
+	// InsecureSkipVerify disables the verification of the Alertmanager certificates.
+	// +optional
+	// +kubebuilder:validation:Optional
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+}
+
+// AlertManagerClientBasicAuthSpec defines basic authentication with the Alertmanagers.
+type AlertManagerClientBasicAuthSpec struct {
+	// Username for basic authentication.
+	// +kubebuilder:validation:Required
+	Username string `json:"username"`
+
+	// Password references the key of a Secret holding the password. It is only
+	// supported for the Alertmanager settings of the stack, as the runtime config
+	// holding the tenant overrides is not a Secret.
+	// +kubebuilder:validation:Required
+	Password corev1.SecretKeySelector `json:"password"`
+}
+
+// AlertManagerClientHeaderAuthSpec defines authentication with credentials in the
+// Authorization header.
+type AlertManagerClientHeaderAuthSpec struct {
+	// Type of the credentials. Defaults to Bearer.
+	// +optional
+	// +kubebuilder:validation:Optional
+	Type string `json:"type,omitempty"`
+
+	// Credentials references the key of a Secret holding the credentials.
+	// +kubebuilder:validation:Required
+	Credentials corev1.SecretKeySelector `json:"credentials"`
+}
+
+type RulerStorageConfig struct {
+	// +kubebuilder:validation:Required
+	Type string `json:"type"`
+
+	// +optional
+	// +kubebuilder:validation:Optional
+	S3 *RulerS3Config `json:"s3,omitempty"`
+}
+
+type RulerS3Config struct {
+	// +kubebuilder:validation:Required
+	BucketNames string `json:"bucketnames"`
+}
+
+// RuntimeConfig 설정 구조체
+type RuntimeConfig struct {
+	// +optional
+	// +kubebuilder:validation:Optional
+	File string `json:"file,omitempty"`
+}
+
+// SchemaConfig 설정 구조체
+type SchemaConfig struct {
+	// +optional
+	// +kubebuilder:validation:Optional
+	Configs []SchemaConfigEntry `json:"configs,omitempty"`
+}
+
+type SchemaConfigEntry struct {
+	// +kubebuilder:validation:Required
+	From string `json:"from"`
+
+	// +optional
+	// +kubebuilder:validation:Optional
+	Index *SchemaConfigIndex `json:"index,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Optional
+	ObjectStore string `json:"objectStore,omitempty"`
+
+	// +kubebuilder:validation:Required
+	Schema string `json:"schema"`
+
+	// +kubebuilder:validation:Required
+	Store string `json:"store"`
+}
+
+type SchemaConfigIndex struct {
+	// +kubebuilder:validation:Required
+	Period string `json:"period"`
+
+	// +kubebuilder:validation:Required
+	Prefix string `json:"prefix"`
+}
+
+// Server 설정 구조체
+type ServerConfig struct {
+	// +optional
+	// +kubebuilder:validation:Optional
+	GRPCListenPort int `json:"grpcListenPort,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Optional
+	HTTPListenPort int `json:"httpListenPort,omitempty"`
+}
+
+// StorageConfig 설정 구조체
+type StorageConfig struct {
+	// +optional
+	// +kubebuilder:validation:Optional
+	BloomShipper *BloomShipperConfig `json:"bloomShipper,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Optional
+	BoltDBShipper *BoltDBShipperConfig `json:"boltdbShipper,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Optional
+	Hedging *HedgingConfig `json:"hedging,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Optional
+	TSDBShipper *TSDBShipperConfig `json:"tsdbShipper,omitempty"`
+}
+
+type BloomShipperConfig struct {
+	// +optional
+	// +kubebuilder:validation:Optional
+	WorkingDirectory string `json:"workingDirectory,omitempty"`
+}
+
+type BoltDBShipperConfig struct {
+	// +optional
+	// +kubebuilder:validation:Optional
+	IndexGatewayClient *IndexGatewayClientConfig `json:"indexGatewayClient,omitempty"`
+}
+
+type IndexGatewayClientConfig struct {
+	// +optional
+	// +kubebuilder:validation:Optional
+	ServerAddress string `json:"serverAddress,omitempty"`
+}
+
+type HedgingConfig struct {
+	// +optional
+	// +kubebuilder:validation:Optional
+	At string `json:"at,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Optional
+	MaxPerSecond int `json:"maxPerSecond,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Optional
+	UpTo int `json:"upTo,omitempty"`
+}
+
+type TSDBShipperConfig struct {
+	// +optional
+	// +kubebuilder:validation:Optional
+	IndexGatewayClient *IndexGatewayClientConfig `json:"indexGatewayClient,omitempty"`
+}
+
+// Tracing 설정 구조체
+type TracingConfig struct {
+	// Enabled defaults to true.
+	// +optional
+	// +kubebuilder:validation:Optional
+	Enabled *bool `json:"enabled,omitempty"`
+}
+
+// SsdLokiSizeType names a preset of replicas, resources and volume sizes for a stack.
+//
+// +kubebuilder:validation:Enum=demo;small;medium;large
+type SsdLokiSizeType string
+
+const (
+	// SizeDemo runs a single replica of each tier with replication factor 1.
+	// It is not meant for production.
+	SizeDemo SsdLokiSizeType = "demo"
+
+	// SizeSmall is sized for a few hundred GB of logs per day.
+	SizeSmall SsdLokiSizeType = "small"
+
+	// SizeMedium is sized for a few TB of logs per day.
+	SizeMedium SsdLokiSizeType = "medium"
+
+	// SizeLarge is sized for tens of TB of logs per day.
+	SizeLarge SsdLokiSizeType = "large"
+)
+
+// SsdLokiTemplateSpec defines the sizing of the read, write and backend tiers.
+type SsdLokiTemplateSpec struct {
+	// +optional
+	// +kubebuilder:validation:Optional
+	Read *SsdLokiComponentSpec `json:"read,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Optional
+	Write *SsdLokiComponentSpec `json:"write,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Optional
+	Backend *SsdLokiComponentSpec `json:"backend,omitempty"`
+}
+
+// SsdLokiComponentSpec defines the sizing of a single tier.
+type SsdLokiComponentSpec struct {
+	// Replicas is the number of pods of the tier.
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// Resources are the CPU and memory requests and limits of the Loki container.
+	// +optional
+	// +kubebuilder:validation:Optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
 
+	// StorageClassName is the storage class of the data volume. The cluster
+	// default is used when empty. It is only applied when the tier is created.
 	// +optional
 	// +kubebuilder:validation:Optional
-	Client *BloomGatewayClient `json:"client,omitempty"`
-}
+	StorageClassName string `json:"storageClassName,omitempty"`
 
-type BloomGatewayClient struct {
-	// +kubebuilder:validation:Required
-	Addresses string `json:"addresses"`
-}
+	// VolumeSize is the size of the data volume of each pod. It is only
+	// applied when the tier is created.
+	// +optional
+	// +kubebuilder:validation:Optional
+	VolumeSize *resource.Quantity `json:"volumeSize,omitempty"`
 
-// ChunkStoreConfig 설정 구조체
-type ChunkStoreConfig struct {
+	// PodTemplate defines placement and metadata overrides of the pods of the tier.
 	// +optional
 	// +kubebuilder:validation:Optional
-	ChunkCacheConfig *ChunkCacheConfig `json:"chunkCacheConfig,omitempty"`
+	PodTemplate *PodTemplateSpec `json:"podTemplate,omitempty"`
 }
 
-type ChunkCacheConfig struct {
+// PodTemplateSpec defines overrides merged into the pods generated for a tier.
+type PodTemplateSpec struct {
+	// Labels are added to the pods. Labels set by the operator take precedence.
 	// +optional
 	// +kubebuilder:validation:Optional
-	Background *CacheBackgroundConfig `json:"background,omitempty"`
+	Labels map[string]string `json:"labels,omitempty"`
 
-	// +kubebuilder:validation:Required
-	DefaultValidity string `json:"defaultValidity"`
+	// Annotations are added to the pods. Annotations set by the operator take
+	// precedence.
+	// +optional
+	// +kubebuilder:validation:Optional
+	Annotations map[string]string `json:"annotations,omitempty"`
 
+	// NodeSelector restricts the pods to nodes with matching labels.
 	// +optional
 	// +kubebuilder:validation:Optional
-	Memcached *MemcachedConfig `json:"memcached,omitempty"`
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
 
+	// Tolerations are added to the pods.
 	// +optional
 	// +kubebuilder:validation:Optional
-	MemcachedClient *MemcachedClientConfig `json:"memcachedClient,omitempty"`
-}
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
 
-type CacheBackgroundConfig struct {
-	// +kubebuilder:validation:Required
-	WritebackBuffer int `json:"writebackBuffer"`
+	// Affinity replaces the node, pod and pod anti-affinity of the pods. Each of
+	// them replaces the generated one only if set, e.g. setting only a node
+	// affinity keeps the generated pod anti-affinity spreading pods over hosts.
+	// +optional
+	// +kubebuilder:validation:Optional
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
 
-	// +kubebuilder:validation:Required
-	WritebackGoroutines int `json:"writebackGoroutines"`
+	// PriorityClassName is the priority class of the pods.
+	// +optional
+	// +kubebuilder:validation:Optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+}
 
-	// +kubebuilder:validation:Required
-	WritebackSizeLimit string `json:"writebackSizeLimit"`
+// ReplicationSpec defines how the pods of a stack are spread over failure domains.
+type ReplicationSpec struct {
+	// Zones defines the node labels the write and backend pods are spread over.
+	// Setting zones also enables zone-aware replication of the ingesters, so that
+	// losing all nodes of one zone does not lose data.
+	// +optional
+	// +kubebuilder:validation:Optional
+	Zones []ZoneSpec `json:"zones,omitempty"`
 }
 
-type MemcachedConfig struct {
+// ZoneSpec defines a topology spread constraint of the write and backend pods.
+type ZoneSpec struct {
+	// TopologyKey is the node label holding the zone of a node,
+	// e.g. topology.kubernetes.io/zone.
 	// +kubebuilder:validation:Required
-	BatchSize int `json:"batchSize"`
+	TopologyKey string `json:"topologyKey"`
 
-	// +kubebuilder:validation:Required
-	Parallelism int `json:"parallelism"`
+	// MaxSkew is the maximum difference of the number of pods of a tier between
+	// any two zones.
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default:=1
+	MaxSkew int32 `json:"maxSkew,omitempty"`
 }
 
-type MemcachedClientConfig struct {
-	// +kubebuilder:validation:Required
-	Addresses string `json:"addresses"`
+// GatewaySpec defines the authenticating gateway in front of the read and write tiers.
+type GatewaySpec struct {
+	// Enabled deploys the gateway. Loki then runs with authentication enabled and
+	// trusts the tenant header set by the gateway for authenticated requests.
+	// +optional
+	// +kubebuilder:validation:Optional
+	Enabled bool `json:"enabled,omitempty"`
 
-	// +kubebuilder:validation:Required
-	ConsistentHash bool `json:"consistentHash"`
+	// Replicas is the number of gateway pods. Defaults to 1.
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	Replicas *int32 `json:"replicas,omitempty"`
 
+	// Resources are the CPU and memory requests and limits of the gateway container.
 	// +optional
 	// +kubebuilder:validation:Optional
-	MaxIdleConns int `json:"maxIdleConns,omitempty"`
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
 
-	// +kubebuilder:validation:Required
-	Timeout string `json:"timeout"`
+	// TLS makes the gateway serve HTTPS. It is required by tenants using mTLS.
+	// +optional
+	// +kubebuilder:validation:Optional
+	TLS *GatewayTLSSpec `json:"tls,omitempty"`
 
+	// Tenants defines the tenants the gateway authenticates and authorizes.
 	// +optional
 	// +kubebuilder:validation:Optional
-	UpdateInterval string `json:"updateInterval,omitempty"`
+	Tenants *TenantsSpec `json:"tenants,omitempty"`
 }
 
-// Common 설정 구조체
-type CommonConfig struct {
+// GatewayTLSSpec references the serving certificate of the gateway.
+type GatewayTLSSpec struct {
+	// SecretName is the name of a Secret of type kubernetes.io/tls in the
+	// namespace of the stack holding tls.crt and tls.key.
 	// +kubebuilder:validation:Required
-	CompactorAddress string `json:"compactorAddress"`
+	SecretName string `json:"secretName"`
+}
 
+// TenantsSpec defines a static set of tenants. Each tenant authenticates either
+// with OIDC or with mTLS, and is authorized by the roles bound to its users.
+type TenantsSpec struct {
+	// Authentication defines the tenants and how their requests are authenticated.
+	// +optional
+	// +kubebuilder:validation:Optional
+	Authentication []AuthenticationSpec `json:"authentication,omitempty"`
+
+	// Authorization defines the roles and role bindings of the tenants.
+	// +optional
+	// +kubebuilder:validation:Optional
+	Authorization *AuthorizationSpec `json:"authorization,omitempty"`
+}
+
+// AuthenticationSpec defines the authentication of a single tenant.
+type AuthenticationSpec struct {
+	// TenantName is the name of the tenant used in URLs and roles.
 	// +kubebuilder:validation:Required
-	PathPrefix string `json:"pathPrefix"`
+	TenantName string `json:"tenantName"`
 
+	// TenantID is the value of the tenant header sent to Loki.
 	// +kubebuilder:validation:Required
-	ReplicationFactor int `json:"replicationFactor"`
+	TenantID string `json:"tenantId"`
 
+	// OIDC authenticates the tenant with an OpenID Connect provider.
 	// +optional
 	// +kubebuilder:validation:Optional
-	Storage *CommonStorage `json:"storage,omitempty"`
-}
+	OIDC *OIDCSpec `json:"oidc,omitempty"`
 
-type CommonStorage struct {
+	// MTLS authenticates the tenant with client certificates.
 	// +optional
 	// +kubebuilder:validation:Optional
-	S3 *S3Config `json:"s3,omitempty"`
+	MTLS *MTLSSpec `json:"mTLS,omitempty"`
 }
 
-type S3Config struct {
+// OIDCSpec defines the OpenID Connect provider of a tenant.
+type OIDCSpec struct {
+	// Secret holds the clientID and optional clientSecret of the tenant.
 	// +kubebuilder:validation:Required
-	AccessKeyID string `json:"accessKeyId"`
+	Secret *TenantSecretSpec `json:"secret"`
 
+	// IssuerURL is the URL of the OIDC provider.
 	// +kubebuilder:validation:Required
-	SecretAccessKey string `json:"secretAccessKey"`
+	IssuerURL string `json:"issuerURL"`
 
-	// +kubebuilder:validation:Required
-	BucketNames string `json:"bucketnames"`
+	// IssuerCA is the CA bundle the certificate of the provider is verified with.
+	// +optional
+	// +kubebuilder:validation:Optional
+	IssuerCA *CASpec `json:"issuerCA,omitempty"`
 
-	// +kubebuilder:validation:Required
-	Endpoint string `json:"endpoint"`
+	// RedirectURL is the URL the provider redirects to after a login.
+	// +optional
+	// +kubebuilder:validation:Optional
+	RedirectURL string `json:"redirectURL,omitempty"`
 
-	// +kubebuilder:validation:Required
-	Insecure bool `json:"insecure"`
+	// GroupClaim is the claim holding the groups of a user.
+	// +optional
+	// +kubebuilder:validation:Optional
+	GroupClaim string `json:"groupClaim,omitempty"`
 
-	// +kubebuilder:validation:Required
-	S3ForcePathStyle bool `json:"s3ForcePathStyle"`
+	// UsernameClaim is the claim holding the name of a user.
+	// +optional
+	// +kubebuilder:validation:Optional
+	UsernameClaim string `json:"usernameClaim,omitempty"`
 }
 
-// Frontend 설정 구조체
-type FrontendConfig struct {
-	// +kubebuilder:validation:Required
-	SchedulerAddress string `json:"schedulerAddress"`
-
+// MTLSSpec defines the client certificate authentication of a tenant.
+type MTLSSpec struct {
+	// CA is the CA bundle client certificates of the tenant are verified with.
 	// +kubebuilder:validation:Required
-	TailProxyURL string `json:"tailProxyUrl"`
+	CA *CASpec `json:"ca"`
 }
 
-type FrontendWorkerConfig struct {
+// TenantSecretSpec references a Secret in the namespace of the stack.
+type TenantSecretSpec struct {
 	// +kubebuilder:validation:Required
-	SchedulerAddress string `json:"schedulerAddress"`
+	Name string `json:"name"`
 }
 
-// IndexGateway 설정 구조체
-type IndexGatewayConfig struct {
+// CASpec references a CA bundle in a ConfigMap in the namespace of the stack.
+type CASpec struct {
+	// CA is the name of the ConfigMap.
 	// +kubebuilder:validation:Required
-	Mode string `json:"mode"`
-}
+	CA string `json:"caName"`
 
-// Ingester 설정 구조체
-type IngesterConfig struct {
-	// +kubebuilder:validation:Required
-	ChunkEncoding string `json:"chunkEncoding"`
+	// CAKey is the key of the CA bundle in the ConfigMap. Defaults to service-ca.crt.
+	// +optional
+	// +kubebuilder:validation:Optional
+	CAKey string `json:"caKey,omitempty"`
 }
 
-// LimitsConfig 설정 구조체
-type LimitsConfig struct {
-	// +kubebuilder:validation:Required
-	MaxCacheFreshnessPerQuery string `json:"maxCacheFreshnessPerQuery"`
+// PermissionType is a permission granted by a role.
+//
+// +kubebuilder:validation:Enum=read;write
+type PermissionType string
 
-	// +kubebuilder:validation:Required
-	QueryTimeout string `json:"queryTimeout"`
+const (
+	// PermissionRead allows queries.
+	PermissionRead PermissionType = "read"
+	// PermissionWrite allows pushing logs.
+	PermissionWrite PermissionType = "write"
+)
+
+// SubjectKind is the kind of a subject bound to a role.
+//
+// +kubebuilder:validation:Enum=user;group
+type SubjectKind string
 
+const (
+	// User is a subject matching the username claim or certificate common name.
+	User SubjectKind = "user"
+	// Group is a subject matching the group claim.
+	Group SubjectKind = "group"
+)
+
+// AuthorizationSpec defines the static authorization of the tenants.
+type AuthorizationSpec struct {
+	// +optional
+	// +kubebuilder:validation:Optional
+	Roles []RoleSpec `json:"roles,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Optional
+	RoleBindings []RoleBindingsSpec `json:"roleBindings,omitempty"`
+}
+
+// RoleSpec grants permissions on resources of tenants.
+type RoleSpec struct {
 	// +kubebuilder:validation:Required
-	RejectOldSamples bool `json:"rejectOldSamples"`
+	Name string `json:"name"`
 
+	// Resources are the resources the role applies to, e.g. logs.
 	// +kubebuilder:validation:Required
-	RejectOldSamplesMaxAge string `json:"rejectOldSamplesMaxAge"`
+	Resources []string `json:"resources"`
 
+	// Tenants are the names of the tenants the role applies to.
 	// +kubebuilder:validation:Required
-	SplitQueriesByInterval string `json:"splitQueriesByInterval"`
+	Tenants []string `json:"tenants"`
 
 	// +kubebuilder:validation:Required
-	VolumeEnabled bool `json:"volumeEnabled"`
+	Permissions []PermissionType `json:"permissions"`
 }
 
-// Memberlist 설정 구조체
-type MemberlistConfig struct {
+// RoleBindingsSpec binds roles to subjects.
+type RoleBindingsSpec struct {
 	// +kubebuilder:validation:Required
-	JoinMembers []string `json:"joinMembers"`
-}
+	Name string `json:"name"`
 
-// PatternIngester 설정 구조체
-type PatternIngesterConfig struct {
 	// +kubebuilder:validation:Required
-	Enabled bool `json:"enabled"`
-}
+	Subjects []Subject `json:"subjects"`
 
-// Querier 설정 구조체
-type QuerierConfig struct {
+	// Roles are the names of the bound roles.
 	// +kubebuilder:validation:Required
-	MaxConcurrent int `json:"maxConcurrent"`
+	Roles []string `json:"roles"`
 }
 
-// QueryRange 설정 구조체
-type QueryRangeConfig struct {
+// Subject is a user or group bound to a role.
+type Subject struct {
 	// +kubebuilder:validation:Required
-	AlignQueriesWithStep bool `json:"alignQueriesWithStep"`
+	Name string `json:"name"`
 
 	// +kubebuilder:validation:Required
-	CacheResults bool `json:"cacheResults"`
+	Kind SubjectKind `json:"kind"`
+}
 
+// TLSProfileType selects the cipher suites and minimum TLS version the Loki
+// components and the gateway accept.
+//
+// +kubebuilder:validation:Enum=Old;Intermediate;Modern
+type TLSProfileType string
+
+const (
+	// TLSProfileOld allows TLS 1.0 and legacy cipher suites for old clients.
+	TLSProfileOld TLSProfileType = "Old"
+	// TLSProfileIntermediate requires TLS 1.2 with AEAD cipher suites.
+	TLSProfileIntermediate TLSProfileType = "Intermediate"
+	// TLSProfileModern requires TLS 1.3.
+	TLSProfileModern TLSProfileType = "Modern"
+)
+
+// InternalTLSSpec encrypts the HTTP and gRPC traffic between the Loki components.
+type InternalTLSSpec struct {
+	// Enabled serves HTTP and gRPC over TLS on every tier and makes every client
+	// between the tiers use TLS.
 	// +optional
 	// +kubebuilder:validation:Optional
-	ResultsCache *ResultsCacheConfig `json:"resultsCache,omitempty"`
-}
+	Enabled bool `json:"enabled,omitempty"`
 
-type ResultsCacheConfig struct {
+	// CA is the CA bundle the certificates of the tiers are verified with.
+	// Required when enabled.
 	// +optional
 	// +kubebuilder:validation:Optional
-	Cache *CacheConfig `json:"cache,omitempty"`
-}
+	CA *CASpec `json:"ca,omitempty"`
 
-type CacheConfig struct {
+	// Certificates are the serving and client certificates of the tiers.
+	// Required when enabled.
 	// +optional
 	// +kubebuilder:validation:Optional
-	Background *CacheBackgroundConfig `json:"background,omitempty"`
-
-	// +kubebuilder:validation:Required
-	DefaultValidity string `json:"defaultValidity"`
+	Certificates *ComponentCertificatesSpec `json:"certificates,omitempty"`
 
+	// Profile selects the cipher suites and minimum TLS version. It also applies
+	// to the gateway. Defaults to Intermediate.
 	// +optional
 	// +kubebuilder:validation:Optional
-	MemcachedClient *MemcachedClientConfig `json:"memcachedClient,omitempty"`
+	Profile TLSProfileType `json:"profile,omitempty"`
 }
 
-// Ruler 설정 구조체
-type RulerConfig struct {
+// ComponentCertificatesSpec references the Secrets of type kubernetes.io/tls
+// holding tls.crt and tls.key of each tier. A certificate must be valid for the
+// Service of its tier and for the memberlist Service of the stack.
+type ComponentCertificatesSpec struct {
 	// +optional
 	// +kubebuilder:validation:Optional
-	Storage *RulerStorageConfig `json:"storage,omitempty"`
-}
+	Read string `json:"read,omitempty"`
 
-type RulerStorageConfig struct {
-	// +kubebuilder:validation:Required
-	Type string `json:"type"`
+	// +optional
+	// +kubebuilder:validation:Optional
+	Write string `json:"write,omitempty"`
 
 	// +optional
 	// +kubebuilder:validation:Optional
-	S3 *RulerS3Config `json:"s3,omitempty"`
+	Backend string `json:"backend,omitempty"`
 }
 
-type RulerS3Config struct {
-	// +kubebuilder:validation:Required
-	BucketNames string `json:"bucketnames"`
-}
+// RulesSpec selects the AlertingRules and RecordingRules evaluated by the ruler.
+type RulesSpec struct {
+	// Enabled loads the selected rules into the ruler.
+	// +optional
+	// +kubebuilder:validation:Optional
+	Enabled bool `json:"enabled,omitempty"`
 
-// RuntimeConfig 설정 구조체
-type RuntimeConfig struct {
-	// +kubebuilder:validation:Required
-	File string `json:"file"`
-}
+	// Selector selects rules by their labels. All rules are selected if it is empty.
+	// +optional
+	// +kubebuilder:validation:Optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
 
-// SchemaConfig 설정 구조체
-type SchemaConfig struct {
-	// +kubebuilder:validation:Required
-	Configs []SchemaConfigEntry `json:"configs"`
+	// NamespaceSelector selects the namespaces rules are discovered in by their
+	// labels. Only the namespace of the stack is searched if it is empty.
+	// +optional
+	// +kubebuilder:validation:Optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
 }
 
-type SchemaConfigEntry struct {
-	// +kubebuilder:validation:Required
-	From string `json:"from"`
-
+// RetentionSpec enables retention on the compactor and defines the retention of
+// all tenants. Tenants override it in their limits.
+type RetentionSpec struct {
+	// Enabled deletes logs older than their retention period and processes log
+	// deletion requests.
 	// +optional
 	// +kubebuilder:validation:Optional
-	Index *SchemaConfigIndex `json:"index,omitempty"`
+	Enabled bool `json:"enabled,omitempty"`
 
-	// +kubebuilder:validation:Required
-	ObjectStore string `json:"objectStore"`
-
-	// +kubebuilder:validation:Required
-	Schema string `json:"schema"`
+	// DeleteWorkerCount is the number of workers deleting expired chunks.
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	DeleteWorkerCount int32 `json:"deleteWorkerCount,omitempty"`
 
-	// +kubebuilder:validation:Required
-	Store string `json:"store"`
+	RetentionLimitSpec `json:",inline"`
 }
 
-type SchemaConfigIndex struct {
-	// +kubebuilder:validation:Required
-	Period string `json:"period"`
+// RetentionLimitSpec defines how long logs are kept.
+type RetentionLimitSpec struct {
+	// Period is how long logs are kept, e.g. 30d. Logs are kept forever if unset.
+	// +optional
+	// +kubebuilder:validation:Optional
+	Period PrometheusDuration `json:"period,omitempty"`
 
-	// +kubebuilder:validation:Required
-	Prefix string `json:"prefix"`
+	// Streams define the retention of the streams matching a selector, taking
+	// precedence over Period.
+	// +optional
+	// +kubebuilder:validation:Optional
+	Streams []RetentionStreamSpec `json:"streams,omitempty"`
 }
 
-// Server 설정 구조체
-type ServerConfig struct {
-	// +kubebuilder:validation:Required
-	GRPCListenPort int `json:"grpcListenPort"`
-
+// RetentionStreamSpec defines the retention of the streams matching a selector.
+type RetentionStreamSpec struct {
+	// Selector is a LogQL stream selector, e.g. {namespace="audit"}.
 	// +kubebuilder:validation:Required
-	HTTPListenPort int `json:"httpListenPort"`
+	// +kubebuilder:validation:MinLength=1
+	Selector string `json:"selector"`
 
-	// +kubebuilder:validation:Required
-	HTTPServerReadTimeout string `json:"httpServerReadTimeout"`
+	// Priority decides between the rules matching a stream. The highest wins.
+	// +optional
+	// +kubebuilder:validation:Optional
+	Priority int32 `json:"priority,omitempty"`
 
+	// Period is how long the matching streams are kept, e.g. 1y.
 	// +kubebuilder:validation:Required
-	HTTPServerWriteTimeout string `json:"httpServerWriteTimeout"`
+	Period PrometheusDuration `json:"period"`
 }
 
-// StorageConfig 설정 구조체
-type StorageConfig struct {
+// SsdLokiSpec 정의
+type SsdLokiSpec struct {
+	// Size selects a preset of replicas, resources and volume sizes for all
+	// tiers. Values set in template take precedence over the preset.
 	// +optional
 	// +kubebuilder:validation:Optional
-	BloomShipper *BloomShipperConfig `json:"bloomShipper,omitempty"`
+	Size SsdLokiSizeType `json:"size,omitempty"`
 
+	// Template defines the replicas, resources and volumes of each tier.
 	// +optional
 	// +kubebuilder:validation:Optional
-	BoltDBShipper *BoltDBShipperConfig `json:"boltdbShipper,omitempty"`
+	Template *SsdLokiTemplateSpec `json:"template,omitempty"`
 
+	// Replication defines the zones the pods of the stack are spread over.
 	// +optional
 	// +kubebuilder:validation:Optional
-	Hedging *HedgingConfig `json:"hedging,omitempty"`
+	Replication *ReplicationSpec `json:"replication,omitempty"`
 
+	// Gateway deploys an authenticating gateway in front of the read and write tiers.
 	// +optional
 	// +kubebuilder:validation:Optional
-	TSDBShipper *TSDBShipperConfig `json:"tsdbShipper,omitempty"`
-}
-
-type BloomShipperConfig struct {
-	// +kubebuilder:validation:Required
-	WorkingDirectory string `json:"workingDirectory"`
-}
+	Gateway *GatewaySpec `json:"gateway,omitempty"`
 
-type BoltDBShipperConfig struct {
+	// TLS encrypts the traffic between the Loki components.
 	// +optional
 	// +kubebuilder:validation:Optional
-	IndexGatewayClient *IndexGatewayClientConfig `json:"indexGatewayClient,omitempty"`
-}
-
-type IndexGatewayClientConfig struct {
-	// +kubebuilder:validation:Required
-	ServerAddress string `json:"serverAddress"`
-}
-
-type HedgingConfig struct {
-	// +kubebuilder:validation:Required
-	At string `json:"at"`
-
-	// +kubebuilder:validation:Required
-	MaxPerSecond int `json:"maxPerSecond"`
-
-	// +kubebuilder:validation:Required
-	UpTo int `json:"upTo"`
-}
+	TLS *InternalTLSSpec `json:"tls,omitempty"`
 
-type TSDBShipperConfig struct {
+	// Rules loads AlertingRules and RecordingRules into the ruler of the backend tier.
 	// +optional
 	// +kubebuilder:validation:Optional
-	IndexGatewayClient *IndexGatewayClientConfig `json:"indexGatewayClient,omitempty"`
-}
+	Rules *RulesSpec `json:"rules,omitempty"`
 
-// Tracing 설정 구조체
-type TracingConfig struct {
-	// +kubebuilder:validation:Required
-	Enabled bool `json:"enabled"`
-}
+	// Retention enables the deletion of logs by the compactor of the backend tier.
+	// +optional
+	// +kubebuilder:validation:Optional
+	Retention *RetentionSpec `json:"retention,omitempty"`
 
-// SsdLokiSpec 정의
-type SsdLokiSpec struct {
-	// +kubebuilder:validation:Required
-	AuthEnabled bool `json:"authEnabled"`
+	// +optional
+	// +kubebuilder:validation:Optional
+	AuthEnabled bool `json:"authEnabled,omitempty"`
 
 	// +optional
 	// +kubebuilder:validation:Optional
@@ -419,6 +1540,12 @@ type SsdLokiSpec struct {
 	// +kubebuilder:validation:Optional
 	LimitsConfig *LimitsConfig `json:"limitsConfig,omitempty"`
 
+	// Overrides defines per-tenant limits keyed by tenant ID. They are rendered
+	// into the runtime config, which Loki reloads without restarting pods.
+	// +optional
+	// +kubebuilder:validation:Optional
+	Overrides map[string]PerTenantLimitsConfig `json:"overrides,omitempty"`
+
 	// +optional
 	// +kubebuilder:validation:Optional
 	Memberlist *MemberlistConfig `json:"memberlist,omitempty"`
@@ -460,6 +1587,72 @@ type SsdLokiSpec struct {
 	Tracing *TracingConfig `json:"tracing,omitempty"`
 }
 
+// SsdLokiConditionType defines the type of conditions reported for a Loki stack.
+type SsdLokiConditionType string
+
+const (
+	// ConditionReady defines the condition that all components of the stack are ready.
+	ConditionReady SsdLokiConditionType = "Ready"
+	// ConditionProgressing defines the condition that some components of the stack are
+	// still being created or rolled out.
+	ConditionProgressing SsdLokiConditionType = "Progressing"
+	// ConditionDegraded defines the condition that the operator cannot reconcile the
+	// stack because of an invalid configuration or missing dependency.
+	ConditionDegraded SsdLokiConditionType = "Degraded"
+)
+
+// SsdLokiConditionReason defines the reasons used in the conditions of a Loki stack.
+type SsdLokiConditionReason string
+
+const (
+	// ReasonReadyComponents when all read, write and backend replicas are ready.
+	ReasonReadyComponents SsdLokiConditionReason = "ReadyComponents"
+	// ReasonPendingComponents when some replicas are not yet ready or updated.
+	ReasonPendingComponents SsdLokiConditionReason = "PendingComponents"
+	// ReasonMissingObjectStorageSecret when the referenced storage Secret does not exist.
+	ReasonMissingObjectStorageSecret SsdLokiConditionReason = "MissingObjectStorageSecret"
+	// ReasonInvalidObjectStorageSecret when the storage Secret lacks required fields.
+	ReasonInvalidObjectStorageSecret SsdLokiConditionReason = "InvalidObjectStorageSecret"
+	// ReasonInvalidObjectStorageConfig when the object storage settings are inconsistent.
+	ReasonInvalidObjectStorageConfig SsdLokiConditionReason = "InvalidObjectStorageConfig"
+	// ReasonInvalidSchemaConfig when the schema config entries are invalid.
+	ReasonInvalidSchemaConfig SsdLokiConditionReason = "InvalidSchemaConfig"
+	// ReasonImmutableVolumeClaims when the volume size or storage class of a tier changed after its StatefulSet was created.
+	ReasonImmutableVolumeClaims SsdLokiConditionReason = "ImmutableVolumeClaims"
+	// ReasonMissingGatewayTenantSecret when a Secret or CA ConfigMap of a gateway tenant does not exist.
+	ReasonMissingGatewayTenantSecret SsdLokiConditionReason = "MissingGatewayTenantSecret"
+	// ReasonInvalidGatewayTenantSecret when a Secret or CA ConfigMap of a gateway tenant lacks required fields.
+	ReasonInvalidGatewayTenantSecret SsdLokiConditionReason = "InvalidGatewayTenantSecret"
+	// ReasonMissingTLSCertificates when a certificate Secret or the CA ConfigMap of internal TLS does not exist.
+	ReasonMissingTLSCertificates SsdLokiConditionReason = "MissingTLSCertificates"
+	// ReasonInvalidTLSCertificates when a certificate Secret or the CA ConfigMap of internal TLS lacks required fields.
+	ReasonInvalidTLSCertificates SsdLokiConditionReason = "InvalidTLSCertificates"
+	// ReasonInvalidRulesSelector when the selector or namespace selector of the rules is invalid.
+	ReasonInvalidRulesSelector SsdLokiConditionReason = "InvalidRulesSelector"
+	// ReasonMissingAlertManagerSecret when a Secret or CA ConfigMap of the Alertmanager client does not exist.
+	ReasonMissingAlertManagerSecret SsdLokiConditionReason = "MissingAlertManagerSecret"
+	// ReasonInvalidAlertManagerSecret when a Secret or CA ConfigMap of the Alertmanager client lacks required fields.
+	ReasonInvalidAlertManagerSecret SsdLokiConditionReason = "InvalidAlertManagerSecret"
+	// ReasonInvalidAlertManagerConfig when an Alertmanager client cannot be configured on the ruler.
+	ReasonInvalidAlertManagerConfig SsdLokiConditionReason = "InvalidAlertManagerConfig"
+	// ReasonMissingRulerSecret when the authorization Secret of the ruler remote write does not exist.
+	ReasonMissingRulerSecret SsdLokiConditionReason = "MissingRulerSecret"
+	// ReasonInvalidRulerSecret when the authorization Secret of the ruler remote write lacks required fields.
+	ReasonInvalidRulerSecret SsdLokiConditionReason = "InvalidRulerSecret"
+)
+
+// SsdLokiPhase is a short summary of the conditions of a Loki stack.
+type SsdLokiPhase string
+
+const (
+	// PhasePending when the stack is progressing towards ready.
+	PhasePending SsdLokiPhase = "Pending"
+	// PhaseReady when all components of the stack are ready.
+	PhaseReady SsdLokiPhase = "Ready"
+	// PhaseDegraded when the stack cannot be reconciled.
+	PhaseDegraded SsdLokiPhase = "Degraded"
+)
+
 // SsdLokiStatus defines the observed state of SsdLoki
 type SsdLokiStatus struct {
 	// +optional
@@ -468,7 +1661,7 @@ type SsdLokiStatus struct {
 
 	// +optional
 	// +kubebuilder:validation:Optional
-	Phase string `json:"phase,omitempty"`
+	Phase SsdLokiPhase `json:"phase,omitempty"`
 
 	// +optional
 	// +kubebuilder:validation:Optional
@@ -478,35 +1671,77 @@ type SsdLokiStatus struct {
 	// +kubebuilder:validation:Optional
 	ComponentStatuses *ComponentStatuses `json:"componentStatuses,omitempty"`
 
+	// IngesterRing is the state of the ingester ring of the write tier.
+	// +optional
+	// +kubebuilder:validation:Optional
+	IngesterRing *RingStatus `json:"ingesterRing,omitempty"`
+
 	// +optional
 	// +kubebuilder:validation:Optional
 	Message string `json:"message,omitempty"`
 }
 
+// ComponentStatuses reports the health of the simple scalable targets of a stack
+// and of the optional components deployed next to them.
 type ComponentStatuses struct {
+	// Read is the status of the read tier StatefulSet.
+	// +optional
+	// +kubebuilder:validation:Optional
+	Read ComponentStatus `json:"read,omitempty"`
+
+	// Write is the status of the write tier StatefulSet.
+	// +optional
+	// +kubebuilder:validation:Optional
+	Write ComponentStatus `json:"write,omitempty"`
+
+	// Backend is the status of the backend tier StatefulSet.
+	// +optional
+	// +kubebuilder:validation:Optional
+	Backend ComponentStatus `json:"backend,omitempty"`
+
+	// Gateway is the status of the gateway pods, if any are deployed.
 	// +optional
 	// +kubebuilder:validation:Optional
-	Ingester ComponentStatus `json:"ingester,omitempty"`
+	Gateway *ComponentStatus `json:"gateway,omitempty"`
 
+	// Memcached is the status of the memcached pods, if any are deployed.
 	// +optional
 	// +kubebuilder:validation:Optional
-	Querier ComponentStatus `json:"querier,omitempty"`
+	Memcached *ComponentStatus `json:"memcached,omitempty"`
 
+	// Canary is the status of the canary pods, if any are deployed.
 	// +optional
 	// +kubebuilder:validation:Optional
-	Distributor ComponentStatus `json:"distributor,omitempty"`
+	Canary *ComponentStatus `json:"canary,omitempty"`
+}
+
+// RingStatus compares the ingester ring as seen by Loki with the write pods as
+// seen by Kubernetes.
+type RingStatus struct {
+	// Members is the number of ingesters registered in the ring.
+	// +optional
+	// +kubebuilder:validation:Optional
+	Members int32 `json:"members,omitempty"`
 
+	// ActiveMembers is the number of ingesters in the ACTIVE state.
 	// +optional
 	// +kubebuilder:validation:Optional
-	QueryFrontend ComponentStatus `json:"queryFrontend,omitempty"`
+	ActiveMembers int32 `json:"activeMembers,omitempty"`
 
+	// ReadyPods is the number of write pods that are ready in Kubernetes.
 	// +optional
 	// +kubebuilder:validation:Optional
-	Ruler ComponentStatus `json:"ruler,omitempty"`
+	ReadyPods int32 `json:"readyPods,omitempty"`
 
+	// ReadyEndpoints is the number of write pods whose /ready endpoint reports ready.
 	// +optional
 	// +kubebuilder:validation:Optional
-	Compactor ComponentStatus `json:"compactor,omitempty"`
+	ReadyEndpoints int32 `json:"readyEndpoints,omitempty"`
+
+	// Message describes why the ring could not be probed, if it could not.
+	// +optional
+	// +kubebuilder:validation:Optional
+	Message string `json:"message,omitempty"`
 }
 
 type ComponentStatus struct {
@@ -529,6 +1764,10 @@ type ComponentStatus struct {
 
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+//+kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status"
+//+kubebuilder:printcolumn:name="Size",type="string",JSONPath=".spec.size"
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 
 // SsdLoki는 ssdlokis API의 스키마입니다
 type SsdLoki struct {
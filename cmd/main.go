@@ -19,7 +19,9 @@ package main
 import (
 	"crypto/tls"
 	"flag"
+	"net/http"
 	"os"
+	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
@@ -36,6 +38,8 @@ import (
 
 	ssdlokiv1 "github.com/ssd-loki/loki-operator/api/v1"
 	"github.com/ssd-loki/loki-operator/internal/controller"
+	"github.com/ssd-loki/loki-operator/internal/defaulting"
+	"github.com/ssd-loki/loki-operator/internal/validation"
 	//+kubebuilder:scaffold:imports
 )
 
@@ -57,6 +61,8 @@ func main() {
 	var probeAddr string
 	var secureMetrics bool
 	var enableHTTP2 bool
+	var ringProbeTimeout time.Duration
+	var deleteRequestPollInterval time.Duration
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
@@ -66,6 +72,10 @@ func main() {
 		"If set the metrics endpoint is served securely")
 	flag.BoolVar(&enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
+	flag.DurationVar(&ringProbeTimeout, "ring-probe-timeout", 5*time.Second,
+		"Timeout of the requests probing the Loki ingester ring for the status. Set to 0 to disable ring probing.")
+	flag.DurationVar(&deleteRequestPollInterval, "delete-request-poll-interval", time.Minute,
+		"How often the compactor is asked for the progress of LokiDeleteRequests.")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -122,13 +132,43 @@ func main() {
 		os.Exit(1)
 	}
 
+	var ringClient *http.Client
+	if ringProbeTimeout > 0 {
+		ringClient = &http.Client{Timeout: ringProbeTimeout}
+	}
+
 	if err = (&controller.SsdLokiReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
+		Client:     mgr.GetClient(),
+		Scheme:     mgr.GetScheme(),
+		HTTPClient: ringClient,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "SsdLoki")
 		os.Exit(1)
 	}
+	if err = (&controller.LokiDeleteRequestReconciler{
+		Client:       mgr.GetClient(),
+		HTTPClient:   &http.Client{Timeout: 30 * time.Second},
+		PollInterval: deleteRequestPollInterval,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "LokiDeleteRequest")
+		os.Exit(1)
+	}
+	if err = (&controller.ZoneAwarePodReconciler{
+		Client: mgr.GetClient(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ZoneAwarePod")
+		os.Exit(1)
+	}
+	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
+		if err = (&defaulting.SsdLokiDefaulter{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "SsdLoki")
+			os.Exit(1)
+		}
+		if err = (&validation.SsdLokiValidator{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "SsdLoki")
+			os.Exit(1)
+		}
+	}
 	//+kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
@@ -0,0 +1,77 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	ssdlokiv1 "github.com/ssd-loki/loki-operator/api/v1"
+	"github.com/ssd-loki/loki-operator/internal/handlers"
+)
+
+// LokiDeleteRequestReconciler submits LokiDeleteRequests to the compactor of
+// their stack and tracks their progress.
+type LokiDeleteRequestReconciler struct {
+	client.Client
+
+	// HTTPClient is used to call the delete API of the compactors.
+	HTTPClient *http.Client
+
+	// PollInterval is how often the compactor is asked for the progress of a
+	// request that is not processed yet.
+	PollInterval time.Duration
+}
+
+//+kubebuilder:rbac:groups=ssd-loki.ssd-loki.com,resources=lokideleterequests,verbs=get;list;watch;update;patch
+//+kubebuilder:rbac:groups=ssd-loki.ssd-loki.com,resources=lokideleterequests/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=ssd-loki.ssd-loki.com,resources=lokideleterequests/finalizers,verbs=update
+//+kubebuilder:rbac:groups=ssd-loki.ssd-loki.com,resources=ssdlokis,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
+
+// Reconcile submits the LokiDeleteRequest to the compactor, or cancels it if the
+// object is being deleted, and requeues it until the compactor has processed it.
+func (r *LokiDeleteRequestReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	pending, err := handlers.CreateOrUpdateLokiDeleteRequest(ctx, logger, req, r.Client, r.HTTPClient)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if pending {
+		return ctrl.Result{RequeueAfter: r.PollInterval}, nil
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager. Status-only updates
+// are ignored, as the reconciler writes them. Deletion bumps the generation, so
+// cancellation is still observed.
+func (r *LokiDeleteRequestReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&ssdlokiv1.LokiDeleteRequest{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(r)
+}
@@ -0,0 +1,231 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	ssdlokiv1 "github.com/ssd-loki/loki-operator/api/v1"
+)
+
+// fakeCompactor is an httptest stand-in for the delete API of the compactor.
+type fakeCompactor struct {
+	mu       sync.Mutex
+	requests []map[string]interface{}
+	// locked rejects cancellation as if the grace period had passed.
+	locked bool
+}
+
+func (f *fakeCompactor) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if r.URL.Path != "/loki/api/v1/delete" || r.Header.Get("X-Scope-OrgID") != "team-a" {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	q := r.URL.Query()
+	switch r.Method {
+	case http.MethodPost:
+		f.requests = append(f.requests, map[string]interface{}{
+			"request_id": fmt.Sprintf("req-%d", len(f.requests)),
+			"query":      q.Get("query"),
+			"start_time": json.Number(q.Get("start")),
+			"end_time":   json.Number(q.Get("end")),
+			"status":     "received",
+		})
+		w.WriteHeader(http.StatusNoContent)
+	case http.MethodGet:
+		_ = json.NewEncoder(w).Encode(f.requests)
+	case http.MethodDelete:
+		for i, req := range f.requests {
+			if req["request_id"] != q.Get("request_id") {
+				continue
+			}
+			if f.locked {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			f.requests = append(f.requests[:i], f.requests[i+1:]...)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (f *fakeCompactor) setStatus(status string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, req := range f.requests {
+		req["status"] = status
+	}
+}
+
+// newCompactorClient serves the fake compactor and returns an HTTP client that
+// connects to it regardless of the requested host.
+func newCompactorClient(t *testing.T, f *fakeCompactor) *http.Client {
+	srv := httptest.NewServer(f)
+	t.Cleanup(srv.Close)
+
+	return &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, network, _ string) (net.Conn, error) {
+				return (&net.Dialer{}).DialContext(ctx, network, srv.Listener.Addr().String())
+			},
+		},
+	}
+}
+
+func newDeleteRequestClient(t *testing.T, objs ...client.Object) client.Client {
+	s := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	if err := ssdlokiv1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+
+	return fake.NewClientBuilder().
+		WithScheme(s).
+		WithObjects(objs...).
+		WithStatusSubresource(&ssdlokiv1.LokiDeleteRequest{}).
+		Build()
+}
+
+func retentionStack() *ssdlokiv1.SsdLoki {
+	return &ssdlokiv1.SsdLoki{
+		ObjectMeta: metav1.ObjectMeta{Name: "loki", Namespace: "ns"},
+		Spec: ssdlokiv1.SsdLokiSpec{
+			Retention: &ssdlokiv1.RetentionSpec{Enabled: true},
+		},
+	}
+}
+
+func deleteRequest() *ssdlokiv1.LokiDeleteRequest {
+	start := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	return &ssdlokiv1.LokiDeleteRequest{
+		ObjectMeta: metav1.ObjectMeta{Name: "leaked-pii", Namespace: "ns"},
+		Spec: ssdlokiv1.LokiDeleteRequestSpec{
+			StackName: "loki",
+			TenantID:  "team-a",
+			Query:     `{app="checkout"} |= "card_number"`,
+			Start:     metav1.NewTime(start),
+			End:       metav1.NewTime(start.Add(24 * time.Hour)),
+		},
+	}
+}
+
+func TestLokiDeleteRequestReconciler_SubmitsAndTracksRequest(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	compactor := &fakeCompactor{}
+	k := newDeleteRequestClient(t, retentionStack(), deleteRequest())
+	r := &LokiDeleteRequestReconciler{Client: k, HTTPClient: newCompactorClient(t, compactor), PollInterval: time.Minute}
+	req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(deleteRequest())}
+
+	res, err := r.Reconcile(ctx, req)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(res.RequeueAfter).To(Equal(time.Minute))
+
+	var got ssdlokiv1.LokiDeleteRequest
+	g.Expect(k.Get(ctx, req.NamespacedName, &got)).To(Succeed())
+	g.Expect(got.Finalizers).To(ContainElement("ssd-loki.ssd-loki.com/delete-request"))
+	g.Expect(got.Status.Phase).To(Equal(ssdlokiv1.DeleteRequestReceived))
+	g.Expect(got.Status.RequestID).To(Equal("req-0"))
+
+	// Polling again must not submit the request twice.
+	_, err = r.Reconcile(ctx, req)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(compactor.requests).To(HaveLen(1))
+
+	compactor.setStatus("processed")
+	res, err = r.Reconcile(ctx, req)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(res.RequeueAfter).To(BeZero())
+
+	g.Expect(k.Get(ctx, req.NamespacedName, &got)).To(Succeed())
+	g.Expect(got.Status.Phase).To(Equal(ssdlokiv1.DeleteRequestProcessed))
+}
+
+func TestLokiDeleteRequestReconciler_DeletionCancelsRequest(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	compactor := &fakeCompactor{}
+	k := newDeleteRequestClient(t, retentionStack(), deleteRequest())
+	r := &LokiDeleteRequestReconciler{Client: k, HTTPClient: newCompactorClient(t, compactor), PollInterval: time.Minute}
+	req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(deleteRequest())}
+
+	_, err := r.Reconcile(ctx, req)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(compactor.requests).To(HaveLen(1))
+
+	g.Expect(k.Delete(ctx, deleteRequest())).To(Succeed())
+	_, err = r.Reconcile(ctx, req)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	g.Expect(compactor.requests).To(BeEmpty())
+	err = k.Get(ctx, req.NamespacedName, &ssdlokiv1.LokiDeleteRequest{})
+	g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+}
+
+func TestLokiDeleteRequestReconciler_DeletionAfterGracePeriod(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	compactor := &fakeCompactor{locked: true}
+	k := newDeleteRequestClient(t, retentionStack(), deleteRequest())
+	r := &LokiDeleteRequestReconciler{Client: k, HTTPClient: newCompactorClient(t, compactor), PollInterval: time.Minute}
+	req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(deleteRequest())}
+
+	_, err := r.Reconcile(ctx, req)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	g.Expect(k.Delete(ctx, deleteRequest())).To(Succeed())
+	_, err = r.Reconcile(ctx, req)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	g.Expect(compactor.requests).To(HaveLen(1))
+	err = k.Get(ctx, req.NamespacedName, &ssdlokiv1.LokiDeleteRequest{})
+	g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+}
+
+func TestLokiDeleteRequestReconciler_RequiresRetention(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	stack := retentionStack()
+	stack.Spec.Retention = nil
+	compactor := &fakeCompactor{}
+	k := newDeleteRequestClient(t, stack, deleteRequest())
+	r := &LokiDeleteRequestReconciler{Client: k, HTTPClient: newCompactorClient(t, compactor), PollInterval: time.Minute}
+	req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(deleteRequest())}
+
+	res, err := r.Reconcile(ctx, req)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(res).To(Equal(ctrl.Result{}))
+
+	var got ssdlokiv1.LokiDeleteRequest
+	g.Expect(k.Get(ctx, req.NamespacedName, &got)).To(Succeed())
+	g.Expect(got.Status.Phase).To(Equal(ssdlokiv1.DeleteRequestFailed))
+	g.Expect(got.Status.Message).To(ContainSubstring("Retention is not enabled"))
+	g.Expect(compactor.requests).To(BeEmpty())
+}
@@ -18,51 +18,158 @@ package controller
 
 import (
 	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"time"
 
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	ssdlokiv1 "github.com/ssd-loki/loki-operator/api/v1"
+	"github.com/ssd-loki/loki-operator/internal/handlers"
+	"github.com/ssd-loki/loki-operator/internal/status"
 )
 
 // SsdLokiReconciler reconciles a SsdLoki object
 type SsdLokiReconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
+
+	// HTTPClient is used to probe the ingester ring of the managed stacks.
+	// Ring probing is disabled when it is nil.
+	HTTPClient *http.Client
 }
 
 //+kubebuilder:rbac:groups=ssd-loki.ssd-loki.com,resources=ssdlokis,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=ssd-loki.ssd-loki.com,resources=ssdlokis/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=ssd-loki.ssd-loki.com,resources=ssdlokis/finalizers,verbs=update
+//+kubebuilder:rbac:groups=ssd-loki.ssd-loki.com,resources=alertingrules;recordingrules,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=configmaps;secrets;services;serviceaccounts,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch
+//+kubebuilder:rbac:groups=apps,resources=deployments;statefulsets,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch;create;update;patch;delete
 
-// Reconcile is part of the main kubernetes reconciliation loop which aims to
-// move the current state of the cluster closer to the desired state.
-// TODO(user): Modify the Reconcile function to compare the state specified by
-// the SsdLoki object against the actual cluster state, and then
-// perform operations to make the cluster state reflect the state specified by
-// the user.
+// Reconcile builds the read, write and backend tiers of the simple scalable
+// deployment described by the SsdLoki object and creates or updates them
+// in the cluster. Afterwards the status of the object is refreshed from the
+// owned StatefulSets, or marked degraded if the spec cannot be reconciled.
 //
 // For more details, check Reconcile and its Result here:
 // - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.17.3/pkg/reconcile
 func (r *SsdLokiReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
-	_ = log.FromContext(ctx)
+	logger := log.FromContext(ctx)
+	logger.Info("Reconciling SsdLoki")
 
-	// TODO(user): your logic here
-	var ssdloki ssdlokiv1.SsdLoki
-	if err := r.Get(ctx, req.NamespacedName, &ssdloki); err != nil {
-		return ctrl.Result{}, client.IgnoreNotFound(err)
+	var degraded *status.DegradedError
+	err := handlers.CreateOrUpdateSsdLoki(ctx, logger, req, r.Client, r.Scheme)
+	switch {
+	case errors.As(err, &degraded):
+		// degraded errors are handled by status.Refresh below
+	case err != nil:
+		return ctrl.Result{}, err
 	}
 
-	log.Log.Info("Reconciling SsdLoki")
+	if err := status.Refresh(ctx, r.Client, r.HTTPClient, req, time.Now(), degraded); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if degraded != nil {
+		return ctrl.Result{Requeue: degraded.Requeue}, nil
+	}
 
 	return ctrl.Result{}, nil
 }
 
 // SetupWithManager sets up the controller with the Manager.
+// Every kind generated by the manifests package is owned by its SsdLoki so that
+// changes to or deletions of child objects trigger a reconcile restoring them.
+// Referenced Secrets and CA ConfigMaps are watched so that rotated credentials
+// and certificates roll out.
+// Status-only updates of the SsdLoki itself are ignored, as the reconciler writes
+// them.
 func (r *SsdLokiReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&ssdlokiv1.SsdLoki{}).
+		For(&ssdlokiv1.SsdLoki{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Owns(&corev1.ConfigMap{}).
+		Owns(&corev1.Secret{}).
+		Owns(&corev1.ServiceAccount{}).
+		Owns(&corev1.Service{}).
+		Owns(&appsv1.Deployment{}).
+		Owns(&appsv1.StatefulSet{}).
+		Owns(&policyv1.PodDisruptionBudget{}).
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.enqueueForReferencedSecret)).
+		Watches(&corev1.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(r.enqueueForReferencedConfigMap)).
+		Watches(&ssdlokiv1.AlertingRule{}, handler.EnqueueRequestsFromMapFunc(r.enqueueForRule)).
+		Watches(&ssdlokiv1.RecordingRule{}, handler.EnqueueRequestsFromMapFunc(r.enqueueForRule)).
 		Complete(r)
 }
+
+// enqueueForReferencedSecret returns a reconcile request for every SsdLoki in the
+// namespace of the Secret that references it.
+func (r *SsdLokiReconciler) enqueueForReferencedSecret(ctx context.Context, obj client.Object) []reconcile.Request {
+	return r.enqueueReferencing(ctx, obj, handlers.ReferencedSecretNames)
+}
+
+// enqueueForReferencedConfigMap returns a reconcile request for every SsdLoki in
+// the namespace of the ConfigMap that references it.
+func (r *SsdLokiReconciler) enqueueForReferencedConfigMap(ctx context.Context, obj client.Object) []reconcile.Request {
+	return r.enqueueReferencing(ctx, obj, handlers.ReferencedConfigMapNames)
+}
+
+// enqueueForRule returns a reconcile request for every SsdLoki in any namespace
+// that selects the AlertingRule or RecordingRule. Deleted rules still carry their
+// labels, so the stacks that loaded them are reconciled as well.
+func (r *SsdLokiReconciler) enqueueForRule(ctx context.Context, obj client.Object) []reconcile.Request {
+	var stacks ssdlokiv1.SsdLokiList
+	if err := r.List(ctx, &stacks); err != nil {
+		log.FromContext(ctx).Error(err, "failed to list ssdlokis for rule", "rule", client.ObjectKeyFromObject(obj))
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for i := range stacks.Items {
+		stack := &stacks.Items[i]
+		if !handlers.SelectsRule(stack, obj) {
+			continue
+		}
+		requests = append(requests, reconcile.Request{
+			NamespacedName: client.ObjectKeyFromObject(stack),
+		})
+	}
+
+	return requests
+}
+
+// enqueueReferencing returns a reconcile request for every SsdLoki in the namespace
+// of obj whose referenced object names include the name of obj.
+func (r *SsdLokiReconciler) enqueueReferencing(ctx context.Context, obj client.Object, referenced func(*ssdlokiv1.SsdLoki) []string) []reconcile.Request {
+	var stacks ssdlokiv1.SsdLokiList
+	if err := r.List(ctx, &stacks, client.InNamespace(obj.GetNamespace())); err != nil {
+		log.FromContext(ctx).Error(err, "failed to list ssdlokis for referenced object", "object", client.ObjectKeyFromObject(obj))
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for i := range stacks.Items {
+		stack := &stacks.Items[i]
+		if !slices.Contains(referenced(stack), obj.GetName()) {
+			continue
+		}
+		requests = append(requests, reconcile.Request{
+			NamespacedName: client.ObjectKeyFromObject(stack),
+		})
+	}
+
+	return requests
+}
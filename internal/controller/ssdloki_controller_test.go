@@ -21,6 +21,7 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -77,8 +78,18 @@ var _ = Describe("SsdLoki Controller", func() {
 				NamespacedName: typeNamespacedName,
 			})
 			Expect(err).NotTo(HaveOccurred())
-			// TODO(user): Add more specific assertions depending on your controller's reconciliation logic.
-			// Example: If you expect a certain status condition after reconciliation, verify it here.
+
+			By("Checking the read, write and backend tiers were created")
+			for _, name := range []string{"loki-read", "loki-write", "loki-backend"} {
+				sts := &appsv1.StatefulSet{}
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Name: name, Namespace: "default"}, sts)).To(Succeed())
+
+				By("Checking " + name + " is owned by the SsdLoki")
+				owner := metav1.GetControllerOf(sts)
+				Expect(owner).NotTo(BeNil())
+				Expect(owner.Kind).To(Equal("SsdLoki"))
+				Expect(owner.Name).To(Equal(resourceName))
+			}
 		})
 	})
 })
@@ -0,0 +1,97 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+
+	"github.com/ViaQ/logerr/kverrors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	"github.com/ssd-loki/loki-operator/internal/manifests"
+)
+
+// ZoneAwarePodReconciler annotates zone-aware Loki pods with the availability
+// zone of the node they are scheduled on.
+type ZoneAwarePodReconciler struct {
+	client.Client
+}
+
+//+kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch;patch;update
+//+kubebuilder:rbac:groups="",resources=nodes,verbs=get;list;watch
+
+// Reconcile copies the zone labels of the node of a scheduled pod into the
+// availability zone annotation of the pod, which releases its init container.
+func (r *ZoneAwarePodReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	var pod corev1.Pod
+	if err := r.Get(ctx, req.NamespacedName, &pod); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, kverrors.Wrap(err, "failed to lookup pod", "name", req.NamespacedName)
+	}
+
+	if pod.Spec.NodeName == "" || pod.Annotations[manifests.AnnotationAvailabilityZone] != "" {
+		return ctrl.Result{}, nil
+	}
+
+	keys := strings.Split(pod.Annotations[manifests.AnnotationAvailabilityZoneLabels], ",")
+
+	var node corev1.Node
+	if err := r.Get(ctx, client.ObjectKey{Name: pod.Spec.NodeName}, &node); err != nil {
+		return ctrl.Result{}, kverrors.Wrap(err, "failed to lookup node", "name", pod.Spec.NodeName)
+	}
+
+	zone, ok := manifests.AvailabilityZone(&node, keys)
+	if !ok {
+		return ctrl.Result{}, kverrors.New("node is missing zone labels", "node", node.Name, "labels", keys)
+	}
+
+	patch := client.MergeFrom(pod.DeepCopy())
+	if pod.Annotations == nil {
+		pod.Annotations = map[string]string{}
+	}
+	pod.Annotations[manifests.AnnotationAvailabilityZone] = zone
+	if err := r.Patch(ctx, &pod, patch); err != nil {
+		return ctrl.Result{}, kverrors.Wrap(err, "failed to annotate pod", "name", req.NamespacedName)
+	}
+
+	logger.Info("Annotated pod with availability zone", "zone", zone)
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager. Only pods labeled
+// as zone-aware by the manifests are watched.
+func (r *ZoneAwarePodReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	zoneAware := predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		return obj.GetLabels()[manifests.LabelZoneAware] == manifests.LabelZoneAwareEnabled
+	})
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("zoneawarepod").
+		For(&corev1.Pod{}, builder.WithPredicates(zoneAware)).
+		Complete(r)
+}
@@ -0,0 +1,73 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/ssd-loki/loki-operator/internal/manifests"
+)
+
+func TestZoneAwarePodReconciler_AnnotatesScheduledPod(t *testing.T) {
+	g := NewWithT(t)
+
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "node-a",
+			Labels: map[string]string{"topology.kubernetes.io/zone": "zone-a"},
+		},
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "loki-write-0",
+			Namespace: "default",
+			Labels:    map[string]string{manifests.LabelZoneAware: manifests.LabelZoneAwareEnabled},
+			Annotations: map[string]string{
+				manifests.AnnotationAvailabilityZoneLabels: "topology.kubernetes.io/zone",
+			},
+		},
+		Spec: corev1.PodSpec{NodeName: "node-a"},
+	}
+
+	k := fake.NewClientBuilder().WithObjects(node, pod).Build()
+	r := &ZoneAwarePodReconciler{Client: k}
+
+	req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(pod)}
+	_, err := r.Reconcile(context.Background(), req)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	var got corev1.Pod
+	g.Expect(k.Get(context.Background(), req.NamespacedName, &got)).To(Succeed())
+	g.Expect(got.Annotations).To(HaveKeyWithValue(manifests.AnnotationAvailabilityZone, "zone-a"))
+}
+
+func TestZoneAwarePodReconciler_SkipsUnscheduledPod(t *testing.T) {
+	g := NewWithT(t)
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "loki-write-0",
+			Namespace: "default",
+			Annotations: map[string]string{
+				manifests.AnnotationAvailabilityZoneLabels: "topology.kubernetes.io/zone",
+			},
+		},
+	}
+
+	k := fake.NewClientBuilder().WithObjects(pod).Build()
+	r := &ZoneAwarePodReconciler{Client: k}
+
+	req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(pod)}
+	_, err := r.Reconcile(context.Background(), req)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	var got corev1.Pod
+	g.Expect(k.Get(context.Background(), req.NamespacedName, &got)).To(Succeed())
+	g.Expect(got.Annotations).NotTo(HaveKey(manifests.AnnotationAvailabilityZone))
+}
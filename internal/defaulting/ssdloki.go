@@ -0,0 +1,43 @@
+package defaulting
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	ssdlokiv1 "github.com/ssd-loki/loki-operator/api/v1"
+	"github.com/ssd-loki/loki-operator/internal/manifests"
+)
+
+//+kubebuilder:webhook:path=/mutate-ssd-loki-ssd-loki-com-v1-ssdloki,mutating=true,failurePolicy=fail,sideEffects=None,groups=ssd-loki.ssd-loki.com,resources=ssdlokis,verbs=create;update,versions=v1,name=mssdloki.ssd-loki.com,admissionReviewVersions=v1
+
+var _ admission.CustomDefaulter = &SsdLokiDefaulter{}
+
+// SsdLokiDefaulter implements a custom defaulter for SsdLoki resources.
+type SsdLokiDefaulter struct{}
+
+// SetupWebhookWithManager registers the SsdLokiDefaulter as a mutating webhook
+// with the controller-runtime manager or returns an error.
+func (d *SsdLokiDefaulter) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&ssdlokiv1.SsdLoki{}).
+		WithDefaulter(d).
+		Complete()
+}
+
+// Default implements admission.CustomDefaulter. It fills every field left
+// unset in the spec with the Loki defaults so that they are visible on the
+// stored object.
+func (d *SsdLokiDefaulter) Default(_ context.Context, obj runtime.Object) error {
+	stack, ok := obj.(*ssdlokiv1.SsdLoki)
+	if !ok {
+		return apierrors.NewBadRequest(fmt.Sprintf("object is not of type SsdLoki: %T", obj))
+	}
+
+	manifests.DefaultSpec(&stack.Spec)
+	return nil
+}
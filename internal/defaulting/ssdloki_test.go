@@ -0,0 +1,62 @@
+package defaulting
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/ptr"
+
+	ssdlokiv1 "github.com/ssd-loki/loki-operator/api/v1"
+)
+
+func TestSsdLokiDefaulter_FillsMinimalSpec(t *testing.T) {
+	g := NewWithT(t)
+
+	stack := &ssdlokiv1.SsdLoki{
+		Spec: ssdlokiv1.SsdLokiSpec{
+			Size: ssdlokiv1.SizeSmall,
+			Common: &ssdlokiv1.CommonConfig{
+				Storage: &ssdlokiv1.CommonStorage{
+					Secret: &ssdlokiv1.ObjectStorageSecretSpec{Name: "s3"},
+					S3:     &ssdlokiv1.S3Config{BucketNames: "logs", Endpoint: "s3.amazonaws.com"},
+				},
+			},
+		},
+	}
+
+	g.Expect((&SsdLokiDefaulter{}).Default(context.Background(), stack)).To(Succeed())
+
+	spec := stack.Spec
+	g.Expect(spec.ChunkStoreConfig.ChunkCacheConfig.MemcachedClient.Timeout).To(Equal("2000ms"))
+	g.Expect(spec.LimitsConfig.QueryTimeout).To(Equal("300s"))
+	g.Expect(spec.Common.PathPrefix).To(Equal("/var/loki"))
+	g.Expect(spec.Common.Storage.S3.BucketNames).To(Equal("logs"))
+	g.Expect(spec.SchemaConfig.Configs).To(HaveLen(1))
+
+	// Sizing is resolved on reconcile so that spec.size can still change.
+	g.Expect(spec.Template).To(BeNil())
+	g.Expect(spec.Common.ReplicationFactor).To(BeZero())
+}
+
+func TestSsdLokiDefaulter_KeepsUserValues(t *testing.T) {
+	g := NewWithT(t)
+
+	stack := &ssdlokiv1.SsdLoki{
+		Spec: ssdlokiv1.SsdLokiSpec{
+			LimitsConfig: &ssdlokiv1.LimitsConfig{
+				QueryTimeout:  "10m",
+				VolumeEnabled: ptr.To(false),
+			},
+			Server: &ssdlokiv1.ServerConfig{HTTPListenPort: 8080},
+		},
+	}
+
+	g.Expect((&SsdLokiDefaulter{}).Default(context.Background(), stack)).To(Succeed())
+
+	g.Expect(stack.Spec.LimitsConfig.QueryTimeout).To(Equal("10m"))
+	g.Expect(stack.Spec.LimitsConfig.VolumeEnabled).To(Equal(ptr.To(false)))
+	g.Expect(stack.Spec.LimitsConfig.SplitQueriesByInterval).To(Equal("15m"))
+	g.Expect(stack.Spec.Server.HTTPListenPort).To(Equal(8080))
+	g.Expect(stack.Spec.Server.GRPCListenPort).To(Equal(9095))
+}
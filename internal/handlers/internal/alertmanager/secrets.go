@@ -0,0 +1,158 @@
+package alertmanager
+
+import (
+	"context"
+	"crypto/sha1"
+	"fmt"
+	"hash"
+	"sort"
+
+	"github.com/ViaQ/logerr/kverrors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ssdlokiv1 "github.com/ssd-loki/loki-operator/api/v1"
+	"github.com/ssd-loki/loki-operator/internal/manifests"
+	"github.com/ssd-loki/loki-operator/internal/status"
+)
+
+// BuildHash reads the Secrets and CA ConfigMaps of the Alertmanager clients of
+// the ruler and returns a hash of the fields in use, so that rotated credentials
+// roll the pods. It returns an empty hash if no client references any. Missing
+// objects and fields, as well as clients the ruler cannot be configured with,
+// are returned as a *status.DegradedError.
+func BuildHash(ctx context.Context, k client.Client, stack *ssdlokiv1.SsdLoki) (string, error) {
+	clients := manifests.AlertManagerClients(stack)
+	if len(clients) == 0 {
+		return "", nil
+	}
+
+	if err := validateOverrides(stack.Spec.Ruler); err != nil {
+		return "", err
+	}
+
+	h := sha1.New()
+	for _, c := range clients {
+		if t := c.TLS; t != nil {
+			if t.CA != nil {
+				if err := writeConfigMapKey(ctx, k, h, stack.Namespace, t.CA.CA, manifests.CAKey(t.CA)); err != nil {
+					return "", err
+				}
+			}
+			if t.CertSecretName != "" {
+				for _, key := range []string{corev1.TLSCertKey, corev1.TLSPrivateKeyKey} {
+					if err := writeSecretKey(ctx, k, h, stack.Namespace, t.CertSecretName, key); err != nil {
+						return "", err
+					}
+				}
+			}
+		}
+		if b := c.BasicAuth; b != nil {
+			if err := writeSecretKey(ctx, k, h, stack.Namespace, b.Password.Name, b.Password.Key); err != nil {
+				return "", err
+			}
+		}
+		if a := c.HeaderAuth; a != nil {
+			if err := writeSecretKey(ctx, k, h, stack.Namespace, a.Credentials.Name, a.Credentials.Key); err != nil {
+				return "", err
+			}
+		}
+	}
+
+	return fmt.Sprintf("%x", h.Sum(nil)), nil
+}
+
+// validateOverrides rejects basic auth in the tenant overrides. The runtime
+// config has no environment to expand the password from, so only the username
+// would reach the ruler.
+func validateOverrides(r *ssdlokiv1.RulerConfig) error {
+	tenants := make([]string, 0, len(r.Overrides))
+	for tenant := range r.Overrides {
+		tenants = append(tenants, tenant)
+	}
+	sort.Strings(tenants)
+
+	for _, tenant := range tenants {
+		am := r.Overrides[tenant].AlertManager
+		if am != nil && am.Client != nil && am.Client.BasicAuth != nil {
+			return &status.DegradedError{
+				Message: fmt.Sprintf("Invalid Alertmanager client of tenant %q: basic auth is not supported in tenant overrides, use headerAuth instead", tenant),
+				Reason:  ssdlokiv1.ReasonInvalidAlertManagerConfig,
+				Requeue: false,
+			}
+		}
+	}
+	return nil
+}
+
+func writeSecretKey(ctx context.Context, k client.Client, h hash.Hash, namespace, name, field string) error {
+	var s corev1.Secret
+	key := client.ObjectKey{Name: name, Namespace: namespace}
+	if err := k.Get(ctx, key, &s); err != nil {
+		if apierrors.IsNotFound(err) {
+			// The secret watch triggers a reconcile once the secret is created.
+			return &status.DegradedError{
+				Message: fmt.Sprintf("Missing Alertmanager client secret %q", name),
+				Reason:  ssdlokiv1.ReasonMissingAlertManagerSecret,
+				Requeue: false,
+			}
+		}
+		return kverrors.Wrap(err, "failed to lookup alertmanager client secret", "name", key)
+	}
+
+	if len(s.Data[field]) == 0 {
+		return &status.DegradedError{
+			Message: fmt.Sprintf("Invalid Alertmanager client secret %q: missing secret field %q", name, field),
+			Reason:  ssdlokiv1.ReasonInvalidAlertManagerSecret,
+			Requeue: false,
+		}
+	}
+
+	_, _ = h.Write(s.Data[field])
+	_, _ = h.Write([]byte{0})
+	return nil
+}
+
+func writeConfigMapKey(ctx context.Context, k client.Client, h hash.Hash, namespace, name, field string) error {
+	var cm corev1.ConfigMap
+	key := client.ObjectKey{Name: name, Namespace: namespace}
+	if err := k.Get(ctx, key, &cm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return &status.DegradedError{
+				Message: fmt.Sprintf("Missing Alertmanager CA configmap %q", name),
+				Reason:  ssdlokiv1.ReasonMissingAlertManagerSecret,
+				Requeue: false,
+			}
+		}
+		return kverrors.Wrap(err, "failed to lookup alertmanager ca configmap", "name", key)
+	}
+
+	if cm.Data[field] == "" {
+		return &status.DegradedError{
+			Message: fmt.Sprintf("Invalid Alertmanager CA configmap %q: missing key %q", name, field),
+			Reason:  ssdlokiv1.ReasonInvalidAlertManagerSecret,
+			Requeue: false,
+		}
+	}
+
+	_, _ = h.Write([]byte(cm.Data[field]))
+	_, _ = h.Write([]byte{0})
+	return nil
+}
+
+// SecretNames returns the names of the Secrets of the Alertmanager clients.
+func SecretNames(stack *ssdlokiv1.SsdLoki) []string {
+	names := manifests.AlertManagerSecretNames(stack)
+	for _, c := range manifests.AlertManagerClients(stack) {
+		if c.BasicAuth != nil {
+			names = append(names, c.BasicAuth.Password.Name)
+		}
+	}
+	return names
+}
+
+// ConfigMapNames returns the names of the CA ConfigMaps of the Alertmanager clients.
+func ConfigMapNames(stack *ssdlokiv1.SsdLoki) []string {
+	return manifests.AlertManagerConfigMapNames(stack)
+}
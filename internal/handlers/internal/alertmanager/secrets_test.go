@@ -0,0 +1,147 @@
+package alertmanager
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	ssdlokiv1 "github.com/ssd-loki/loki-operator/api/v1"
+	"github.com/ssd-loki/loki-operator/internal/status"
+)
+
+func alertManagerStack() *ssdlokiv1.SsdLoki {
+	return &ssdlokiv1.SsdLoki{
+		ObjectMeta: metav1.ObjectMeta{Name: "loki", Namespace: "ns"},
+		Spec: ssdlokiv1.SsdLokiSpec{
+			Ruler: &ssdlokiv1.RulerConfig{
+				AlertManager: &ssdlokiv1.AlertManagerSpec{
+					AlertManagerOverrideSpec: ssdlokiv1.AlertManagerOverrideSpec{
+						Endpoints: []string{"https://alertmanager.monitoring.svc:9093"},
+						Client: &ssdlokiv1.AlertManagerClientSpec{
+							TLS: &ssdlokiv1.AlertManagerClientTLSSpec{
+								CA: &ssdlokiv1.CASpec{CA: "alertmanager-ca"},
+							},
+							BasicAuth: &ssdlokiv1.AlertManagerClientBasicAuthSpec{
+								Username: "loki",
+								Password: corev1.SecretKeySelector{
+									LocalObjectReference: corev1.LocalObjectReference{Name: "alertmanager-auth"},
+									Key:                  "password",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func caConfigMap() *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "alertmanager-ca", Namespace: "ns"},
+		Data:       map[string]string{"service-ca.crt": "pem"},
+	}
+}
+
+func authSecret(password string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "alertmanager-auth", Namespace: "ns"},
+		Data:       map[string][]byte{"password": []byte(password)},
+	}
+}
+
+func TestBuildHash(t *testing.T) {
+	g := NewWithT(t)
+
+	k := fake.NewClientBuilder().WithObjects(caConfigMap(), authSecret("secret")).Build()
+	hash, err := BuildHash(context.Background(), k, alertManagerStack())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(hash).NotTo(BeEmpty())
+
+	k = fake.NewClientBuilder().WithObjects(caConfigMap(), authSecret("rotated")).Build()
+	rotated, err := BuildHash(context.Background(), k, alertManagerStack())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(rotated).NotTo(Equal(hash))
+
+	stack := alertManagerStack()
+	stack.Spec.Ruler.AlertManager.Client = nil
+	hash, err = BuildHash(context.Background(), fake.NewClientBuilder().Build(), stack)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(hash).To(BeEmpty())
+}
+
+func TestBuildHash_DegradedReasons(t *testing.T) {
+	tt := []struct {
+		desc       string
+		mutate     func(*ssdlokiv1.SsdLoki)
+		objs       []client.Object
+		wantReason ssdlokiv1.SsdLokiConditionReason
+	}{
+		{
+			desc:       "missing ca configmap",
+			objs:       []client.Object{authSecret("secret")},
+			wantReason: ssdlokiv1.ReasonMissingAlertManagerSecret,
+		},
+		{
+			desc:       "missing password secret",
+			objs:       []client.Object{caConfigMap()},
+			wantReason: ssdlokiv1.ReasonMissingAlertManagerSecret,
+		},
+		{
+			desc: "password secret without key",
+			objs: []client.Object{
+				caConfigMap(),
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "alertmanager-auth", Namespace: "ns"},
+					Data:       map[string][]byte{"username": []byte("loki")},
+				},
+			},
+			wantReason: ssdlokiv1.ReasonInvalidAlertManagerSecret,
+		},
+		{
+			desc: "basic auth in tenant override",
+			mutate: func(stack *ssdlokiv1.SsdLoki) {
+				stack.Spec.Ruler.Overrides = map[string]ssdlokiv1.RulerOverridesSpec{
+					"team-a": {
+						AlertManager: &ssdlokiv1.AlertManagerOverrideSpec{
+							Client: stack.Spec.Ruler.AlertManager.Client,
+						},
+					},
+				}
+			},
+			objs:       []client.Object{caConfigMap(), authSecret("secret")},
+			wantReason: ssdlokiv1.ReasonInvalidAlertManagerConfig,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.desc, func(t *testing.T) {
+			g := NewWithT(t)
+
+			stack := alertManagerStack()
+			if tc.mutate != nil {
+				tc.mutate(stack)
+			}
+			k := fake.NewClientBuilder().WithObjects(tc.objs...).Build()
+
+			_, err := BuildHash(context.Background(), k, stack)
+
+			var degraded *status.DegradedError
+			g.Expect(errors.As(err, &degraded)).To(BeTrue())
+			g.Expect(degraded.Reason).To(Equal(tc.wantReason))
+		})
+	}
+}
+
+func TestSecretNames(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(SecretNames(alertManagerStack())).To(ConsistOf("alertmanager-auth"))
+	g.Expect(ConfigMapNames(alertManagerStack())).To(ConsistOf("alertmanager-ca"))
+}
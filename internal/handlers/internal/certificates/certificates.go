@@ -0,0 +1,147 @@
+package certificates
+
+import (
+	"context"
+	"crypto/sha1"
+	"fmt"
+	"hash"
+	"sort"
+
+	"github.com/ViaQ/logerr/kverrors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ssdlokiv1 "github.com/ssd-loki/loki-operator/api/v1"
+	"github.com/ssd-loki/loki-operator/internal/manifests"
+	"github.com/ssd-loki/loki-operator/internal/status"
+)
+
+var components = []string{
+	manifests.ComponentRead,
+	manifests.ComponentWrite,
+	manifests.ComponentBackend,
+}
+
+// BuildHash reads the CA bundle and the certificates of internal TLS and returns
+// a hash of their contents, so that rotated certificates roll the pods. It returns
+// an empty hash if internal TLS is disabled. Missing or incomplete objects are
+// returned as a *status.DegradedError.
+func BuildHash(ctx context.Context, k client.Client, stack *ssdlokiv1.SsdLoki) (string, error) {
+	if !manifests.InternalTLSEnabled(stack) {
+		return "", nil
+	}
+
+	spec := stack.Spec.TLS
+	if spec.CA == nil || spec.Certificates == nil {
+		return "", &status.DegradedError{
+			Message: "Internal TLS requires a CA and a certificate for every tier",
+			Reason:  ssdlokiv1.ReasonInvalidTLSCertificates,
+			Requeue: false,
+		}
+	}
+
+	h := sha1.New()
+
+	var cm corev1.ConfigMap
+	key := client.ObjectKey{Name: spec.CA.CA, Namespace: stack.Namespace}
+	if err := k.Get(ctx, key, &cm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", &status.DegradedError{
+				Message: fmt.Sprintf("Missing CA configmap %q", key.Name),
+				Reason:  ssdlokiv1.ReasonMissingTLSCertificates,
+				Requeue: false,
+			}
+		}
+		return "", kverrors.Wrap(err, "failed to lookup ca configmap", "name", key)
+	}
+
+	caKey := manifests.CAKey(spec.CA)
+	ca := cm.Data[caKey]
+	if ca == "" {
+		return "", &status.DegradedError{
+			Message: fmt.Sprintf("Invalid CA configmap %q: missing key %q", key.Name, caKey),
+			Reason:  ssdlokiv1.ReasonInvalidTLSCertificates,
+			Requeue: false,
+		}
+	}
+	_, _ = h.Write([]byte(ca))
+	_, _ = h.Write([]byte{0})
+
+	for _, component := range components {
+		name := manifests.CertificateSecretName(stack, component)
+		if name == "" {
+			return "", &status.DegradedError{
+				Message: fmt.Sprintf("Missing certificate secret name for the %s tier", component),
+				Reason:  ssdlokiv1.ReasonInvalidTLSCertificates,
+				Requeue: false,
+			}
+		}
+
+		var s corev1.Secret
+		key := client.ObjectKey{Name: name, Namespace: stack.Namespace}
+		if err := k.Get(ctx, key, &s); err != nil {
+			if apierrors.IsNotFound(err) {
+				// The secret watch triggers a reconcile once the secret is created.
+				return "", &status.DegradedError{
+					Message: fmt.Sprintf("Missing certificate secret %q of the %s tier", name, component),
+					Reason:  ssdlokiv1.ReasonMissingTLSCertificates,
+					Requeue: false,
+				}
+			}
+			return "", kverrors.Wrap(err, "failed to lookup certificate secret", "name", key)
+		}
+
+		for _, field := range []string{corev1.TLSCertKey, corev1.TLSPrivateKeyKey} {
+			if len(s.Data[field]) == 0 {
+				return "", &status.DegradedError{
+					Message: fmt.Sprintf("Invalid certificate secret %q: missing secret field %q", name, field),
+					Reason:  ssdlokiv1.ReasonInvalidTLSCertificates,
+					Requeue: false,
+				}
+			}
+		}
+		writeSecretData(h, &s)
+	}
+
+	return fmt.Sprintf("%x", h.Sum(nil)), nil
+}
+
+// writeSecretData writes all data of the Secret to the hash in a stable order.
+func writeSecretData(h hash.Hash, s *corev1.Secret) {
+	keys := make([]string, 0, len(s.Data))
+	for k := range s.Data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		_, _ = h.Write([]byte(k))
+		_, _ = h.Write([]byte{0})
+		_, _ = h.Write(s.Data[k])
+		_, _ = h.Write([]byte{0})
+	}
+}
+
+// SecretNames returns the names of the certificate Secrets of internal TLS.
+func SecretNames(stack *ssdlokiv1.SsdLoki) []string {
+	if !manifests.InternalTLSEnabled(stack) {
+		return nil
+	}
+
+	var names []string
+	for _, component := range components {
+		if name := manifests.CertificateSecretName(stack, component); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// ConfigMapNames returns the name of the CA ConfigMap of internal TLS.
+func ConfigMapNames(stack *ssdlokiv1.SsdLoki) []string {
+	if !manifests.InternalTLSEnabled(stack) || stack.Spec.TLS.CA == nil {
+		return nil
+	}
+	return []string{stack.Spec.TLS.CA.CA}
+}
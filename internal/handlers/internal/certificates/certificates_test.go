@@ -0,0 +1,139 @@
+package certificates
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	ssdlokiv1 "github.com/ssd-loki/loki-operator/api/v1"
+	"github.com/ssd-loki/loki-operator/internal/status"
+)
+
+func tlsStack() *ssdlokiv1.SsdLoki {
+	return &ssdlokiv1.SsdLoki{
+		ObjectMeta: metav1.ObjectMeta{Name: "loki", Namespace: "ns"},
+		Spec: ssdlokiv1.SsdLokiSpec{
+			TLS: &ssdlokiv1.InternalTLSSpec{
+				Enabled: true,
+				CA:      &ssdlokiv1.CASpec{CA: "loki-ca"},
+				Certificates: &ssdlokiv1.ComponentCertificatesSpec{
+					Read:    "loki-read-tls",
+					Write:   "loki-write-tls",
+					Backend: "loki-backend-tls",
+				},
+			},
+		},
+	}
+}
+
+func caConfigMap() *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "loki-ca", Namespace: "ns"},
+		Data:       map[string]string{"service-ca.crt": "pem"},
+	}
+}
+
+func certificateSecret(name, cert string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns"},
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       []byte(cert),
+			corev1.TLSPrivateKeyKey: []byte("key"),
+		},
+	}
+}
+
+func TestBuildHash(t *testing.T) {
+	g := NewWithT(t)
+
+	objs := func(writeCert string) []client.Object {
+		return []client.Object{
+			caConfigMap(),
+			certificateSecret("loki-read-tls", "read"),
+			certificateSecret("loki-write-tls", writeCert),
+			certificateSecret("loki-backend-tls", "backend"),
+		}
+	}
+
+	k := fake.NewClientBuilder().WithObjects(objs("write")...).Build()
+	hash, err := BuildHash(context.Background(), k, tlsStack())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(hash).NotTo(BeEmpty())
+
+	k = fake.NewClientBuilder().WithObjects(objs("rotated")...).Build()
+	rotated, err := BuildHash(context.Background(), k, tlsStack())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(rotated).NotTo(Equal(hash))
+
+	disabled := tlsStack()
+	disabled.Spec.TLS.Enabled = false
+	hash, err = BuildHash(context.Background(), fake.NewClientBuilder().Build(), disabled)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(hash).To(BeEmpty())
+}
+
+func TestBuildHash_DegradedReasons(t *testing.T) {
+	certs := []client.Object{
+		certificateSecret("loki-read-tls", "read"),
+		certificateSecret("loki-write-tls", "write"),
+		certificateSecret("loki-backend-tls", "backend"),
+	}
+
+	tt := []struct {
+		desc       string
+		objs       []client.Object
+		wantReason ssdlokiv1.SsdLokiConditionReason
+	}{
+		{
+			desc:       "missing ca configmap",
+			objs:       certs,
+			wantReason: ssdlokiv1.ReasonMissingTLSCertificates,
+		},
+		{
+			desc: "ca configmap without key",
+			objs: append([]client.Object{
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Name: "loki-ca", Namespace: "ns"},
+					Data:       map[string]string{"ca.crt": "pem"},
+				},
+			}, certs...),
+			wantReason: ssdlokiv1.ReasonInvalidTLSCertificates,
+		},
+		{
+			desc:       "missing certificate secret",
+			objs:       []client.Object{caConfigMap(), certs[0], certs[1]},
+			wantReason: ssdlokiv1.ReasonMissingTLSCertificates,
+		},
+		{
+			desc: "certificate secret without private key",
+			objs: []client.Object{
+				caConfigMap(), certs[0], certs[1],
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "loki-backend-tls", Namespace: "ns"},
+					Data:       map[string][]byte{corev1.TLSCertKey: []byte("backend")},
+				},
+			},
+			wantReason: ssdlokiv1.ReasonInvalidTLSCertificates,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.desc, func(t *testing.T) {
+			g := NewWithT(t)
+
+			k := fake.NewClientBuilder().WithObjects(tc.objs...).Build()
+
+			_, err := BuildHash(context.Background(), k, tlsStack())
+
+			var degraded *status.DegradedError
+			g.Expect(errors.As(err, &degraded)).To(BeTrue())
+			g.Expect(degraded.Reason).To(Equal(tc.wantReason))
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package compactor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/ViaQ/logerr/kverrors"
+)
+
+const (
+	// StatusReceived is the status of a delete request waiting for the
+	// cancellation grace period of the compactor to pass.
+	StatusReceived = "received"
+	// StatusProcessed is the status of a delete request whose logs are deleted.
+	StatusProcessed = "processed"
+
+	// tenantHeader selects the tenant of a request to Loki.
+	tenantHeader = "X-Scope-OrgID"
+)
+
+// ErrCancelNotAllowed is returned by Cancel once the compactor started
+// processing the delete request.
+var ErrCancelNotAllowed = errors.New("delete request can no longer be cancelled")
+
+// DeleteRequest is a delete request as listed by the compactor. Start and end
+// are in seconds since the epoch.
+type DeleteRequest struct {
+	RequestID string  `json:"request_id"`
+	StartTime float64 `json:"start_time"`
+	EndTime   float64 `json:"end_time"`
+	Query     string  `json:"query"`
+	Status    string  `json:"status"`
+}
+
+// Matches reports whether the delete request deletes the logs matching query
+// between start and end, compared at the precision of seconds the compactor is
+// submitted with.
+func (r DeleteRequest) Matches(query string, start, end time.Time) bool {
+	return r.Query == query &&
+		int64(math.Round(r.StartTime)) == start.Unix() &&
+		int64(math.Round(r.EndTime)) == end.Unix()
+}
+
+// Submit asks the compactor at endpoint to delete the logs of tenant matching
+// query between start and end.
+func Submit(ctx context.Context, hc *http.Client, endpoint, tenant, query string, start, end time.Time) error {
+	params := url.Values{}
+	params.Set("query", query)
+	params.Set("start", strconv.FormatInt(start.Unix(), 10))
+	params.Set("end", strconv.FormatInt(end.Unix(), 10))
+
+	resp, err := do(ctx, hc, http.MethodPost, endpoint, tenant, params)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+		return unexpectedResponse(resp, "failed to submit delete request")
+	}
+	return nil
+}
+
+// List returns the delete requests of tenant known to the compactor at endpoint.
+func List(ctx context.Context, hc *http.Client, endpoint, tenant string) ([]DeleteRequest, error) {
+	resp, err := do(ctx, hc, http.MethodGet, endpoint, tenant, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, unexpectedResponse(resp, "failed to list delete requests")
+	}
+
+	var requests []DeleteRequest
+	if err := json.NewDecoder(resp.Body).Decode(&requests); err != nil {
+		return nil, kverrors.Wrap(err, "failed to decode delete requests", "url", endpoint)
+	}
+	return requests, nil
+}
+
+// Cancel cancels the delete request of tenant with the given ID. Requests the
+// compactor no longer knows are considered cancelled. ErrCancelNotAllowed is
+// returned once the request is being processed.
+func Cancel(ctx context.Context, hc *http.Client, endpoint, tenant, id string) error {
+	params := url.Values{}
+	params.Set("request_id", id)
+
+	resp, err := do(ctx, hc, http.MethodDelete, endpoint, tenant, params)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusNoContent, http.StatusOK, http.StatusNotFound:
+		return nil
+	case http.StatusBadRequest:
+		return ErrCancelNotAllowed
+	}
+	return unexpectedResponse(resp, "failed to cancel delete request")
+}
+
+func do(ctx context.Context, hc *http.Client, method, endpoint, tenant string, params url.Values) (*http.Response, error) {
+	u := endpoint
+	if len(params) > 0 {
+		u += "?" + params.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u, nil)
+	if err != nil {
+		return nil, kverrors.Wrap(err, "failed to create delete request", "url", endpoint)
+	}
+	req.Header.Set(tenantHeader, tenant)
+	req.Header.Set("Accept", "application/json")
+
+	return hc.Do(req)
+}
+
+func unexpectedResponse(resp *http.Response, msg string) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return kverrors.New(msg, "status", resp.StatusCode, "response", string(body))
+}
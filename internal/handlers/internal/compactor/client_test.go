@@ -0,0 +1,133 @@
+package compactor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+// fakeCompactor is a stand-in for the delete API of the Loki compactor keeping
+// the delete requests of every tenant in memory.
+type fakeCompactor struct {
+	mu       sync.Mutex
+	requests map[string][]DeleteRequest
+	// locked rejects cancellation as if the grace period had passed.
+	locked bool
+}
+
+func newFakeCompactor() *fakeCompactor {
+	return &fakeCompactor{requests: map[string][]DeleteRequest{}}
+}
+
+func (f *fakeCompactor) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	tenant := r.Header.Get(tenantHeader)
+	if tenant == "" || r.URL.Path != "/loki/api/v1/delete" {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	q := r.URL.Query()
+	switch r.Method {
+	case http.MethodPost:
+		start, _ := strconv.ParseFloat(q.Get("start"), 64)
+		end, _ := strconv.ParseFloat(q.Get("end"), 64)
+		f.requests[tenant] = append(f.requests[tenant], DeleteRequest{
+			RequestID: fmt.Sprintf("req-%d", len(f.requests[tenant])),
+			StartTime: start,
+			EndTime:   end,
+			Query:     q.Get("query"),
+			Status:    StatusReceived,
+		})
+		w.WriteHeader(http.StatusNoContent)
+	case http.MethodGet:
+		_ = json.NewEncoder(w).Encode(append([]DeleteRequest{}, f.requests[tenant]...))
+	case http.MethodDelete:
+		for i, req := range f.requests[tenant] {
+			if req.RequestID != q.Get("request_id") {
+				continue
+			}
+			if f.locked {
+				http.Error(w, "deletion of request which is in process or already processed is not allowed", http.StatusBadRequest)
+				return
+			}
+			f.requests[tenant] = append(f.requests[tenant][:i], f.requests[tenant][i+1:]...)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		http.Error(w, "could not find delete request with given id", http.StatusNotFound)
+	}
+}
+
+func TestSubmitListCancel(t *testing.T) {
+	g := NewWithT(t)
+
+	srv := httptest.NewServer(newFakeCompactor())
+	defer srv.Close()
+	endpoint := srv.URL + "/loki/api/v1/delete"
+	ctx := context.Background()
+
+	start := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(24 * time.Hour)
+	query := `{app="checkout"} |= "card_number"`
+
+	g.Expect(Submit(ctx, srv.Client(), endpoint, "team-a", query, start, end)).To(Succeed())
+
+	requests, err := List(ctx, srv.Client(), endpoint, "team-a")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(requests).To(HaveLen(1))
+	g.Expect(requests[0].Matches(query, start, end)).To(BeTrue())
+	g.Expect(requests[0].Matches(query, start, end.Add(time.Hour))).To(BeFalse())
+	g.Expect(requests[0].Status).To(Equal(StatusReceived))
+
+	requests, err = List(ctx, srv.Client(), endpoint, "team-b")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(requests).To(BeEmpty())
+
+	g.Expect(Cancel(ctx, srv.Client(), endpoint, "team-a", "req-0")).To(Succeed())
+	requests, err = List(ctx, srv.Client(), endpoint, "team-a")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(requests).To(BeEmpty())
+
+	// Requests the compactor no longer knows are considered cancelled.
+	g.Expect(Cancel(ctx, srv.Client(), endpoint, "team-a", "req-0")).To(Succeed())
+}
+
+func TestCancel_NotAllowed(t *testing.T) {
+	g := NewWithT(t)
+
+	f := newFakeCompactor()
+	f.locked = true
+	srv := httptest.NewServer(f)
+	defer srv.Close()
+	endpoint := srv.URL + "/loki/api/v1/delete"
+	ctx := context.Background()
+
+	start := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	g.Expect(Submit(ctx, srv.Client(), endpoint, "fake", `{app="checkout"}`, start, start.Add(time.Hour))).To(Succeed())
+
+	err := Cancel(ctx, srv.Client(), endpoint, "fake", "req-0")
+	g.Expect(err).To(MatchError(ErrCancelNotAllowed))
+}
+
+func TestList_UnexpectedResponse(t *testing.T) {
+	g := NewWithT(t)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "compactor not running", http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	_, err := List(context.Background(), srv.Client(), srv.URL+"/loki/api/v1/delete", "fake")
+	g.Expect(err).To(HaveOccurred())
+}
@@ -0,0 +1,144 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ViaQ/logerr/kverrors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ssdlokiv1 "github.com/ssd-loki/loki-operator/api/v1"
+	"github.com/ssd-loki/loki-operator/internal/manifests"
+	"github.com/ssd-loki/loki-operator/internal/status"
+)
+
+const (
+	// KeyClientID is the key of the OIDC client ID in a tenant Secret.
+	KeyClientID = "clientID"
+	// KeyClientSecret is the optional key of the OIDC client secret in a tenant Secret.
+	KeyClientSecret = "clientSecret"
+)
+
+// BuildTenantSecrets reads the OIDC client credentials of the gateway tenants and
+// checks that the CA ConfigMaps they reference exist. Missing or incomplete
+// objects are returned as a *status.DegradedError.
+func BuildTenantSecrets(ctx context.Context, k client.Client, stack *ssdlokiv1.SsdLoki) ([]*manifests.TenantSecrets, error) {
+	spec := tenantsSpec(stack)
+	if spec == nil {
+		return nil, nil
+	}
+
+	var secrets []*manifests.TenantSecrets
+	for _, a := range spec.Authentication {
+		if ca := manifests.TenantCA(a); ca != nil {
+			if err := checkCA(ctx, k, stack.Namespace, a.TenantName, ca); err != nil {
+				return nil, err
+			}
+		}
+
+		if a.OIDC == nil || a.OIDC.Secret == nil {
+			continue
+		}
+
+		var s corev1.Secret
+		key := client.ObjectKey{Name: a.OIDC.Secret.Name, Namespace: stack.Namespace}
+		if err := k.Get(ctx, key, &s); err != nil {
+			if apierrors.IsNotFound(err) {
+				// The secret watch triggers a reconcile once the secret is created.
+				return nil, &status.DegradedError{
+					Message: fmt.Sprintf("Missing secret %q of gateway tenant %q", key.Name, a.TenantName),
+					Reason:  ssdlokiv1.ReasonMissingGatewayTenantSecret,
+					Requeue: false,
+				}
+			}
+			return nil, kverrors.Wrap(err, "failed to lookup gateway tenant secret", "name", key)
+		}
+
+		clientID := s.Data[KeyClientID]
+		if len(clientID) == 0 {
+			return nil, &status.DegradedError{
+				Message: fmt.Sprintf("Invalid secret %q of gateway tenant %q: missing secret field %q", key.Name, a.TenantName, KeyClientID),
+				Reason:  ssdlokiv1.ReasonInvalidGatewayTenantSecret,
+				Requeue: false,
+			}
+		}
+
+		secrets = append(secrets, &manifests.TenantSecrets{
+			TenantName: a.TenantName,
+			OIDCSecret: &manifests.OIDCSecret{
+				ClientID:     string(clientID),
+				ClientSecret: string(s.Data[KeyClientSecret]),
+			},
+		})
+	}
+
+	return secrets, nil
+}
+
+func checkCA(ctx context.Context, k client.Client, namespace, tenantName string, ca *ssdlokiv1.CASpec) error {
+	var cm corev1.ConfigMap
+	key := client.ObjectKey{Name: ca.CA, Namespace: namespace}
+	if err := k.Get(ctx, key, &cm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return &status.DegradedError{
+				Message: fmt.Sprintf("Missing CA configmap %q of gateway tenant %q", key.Name, tenantName),
+				Reason:  ssdlokiv1.ReasonMissingGatewayTenantSecret,
+				Requeue: false,
+			}
+		}
+		return kverrors.Wrap(err, "failed to lookup gateway tenant ca configmap", "name", key)
+	}
+
+	caKey := manifests.CAKey(ca)
+	if cm.Data[caKey] == "" {
+		return &status.DegradedError{
+			Message: fmt.Sprintf("Invalid CA configmap %q of gateway tenant %q: missing key %q", key.Name, tenantName, caKey),
+			Reason:  ssdlokiv1.ReasonInvalidGatewayTenantSecret,
+			Requeue: false,
+		}
+	}
+
+	return nil
+}
+
+// SecretNames returns the names of the OIDC Secrets of the gateway tenants.
+func SecretNames(stack *ssdlokiv1.SsdLoki) []string {
+	spec := tenantsSpec(stack)
+	if spec == nil {
+		return nil
+	}
+
+	var names []string
+	for _, a := range spec.Authentication {
+		if a.OIDC != nil && a.OIDC.Secret != nil {
+			names = append(names, a.OIDC.Secret.Name)
+		}
+	}
+	return names
+}
+
+// ConfigMapNames returns the names of the CA ConfigMaps of the gateway tenants.
+func ConfigMapNames(stack *ssdlokiv1.SsdLoki) []string {
+	spec := tenantsSpec(stack)
+	if spec == nil {
+		return nil
+	}
+
+	var names []string
+	for _, a := range spec.Authentication {
+		if ca := manifests.TenantCA(a); ca != nil {
+			names = append(names, ca.CA)
+		}
+	}
+	return names
+}
+
+func tenantsSpec(stack *ssdlokiv1.SsdLoki) *ssdlokiv1.TenantsSpec {
+	gw := stack.Spec.Gateway
+	if gw == nil || !gw.Enabled {
+		return nil
+	}
+	return gw.Tenants
+}
@@ -0,0 +1,129 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	ssdlokiv1 "github.com/ssd-loki/loki-operator/api/v1"
+	"github.com/ssd-loki/loki-operator/internal/status"
+)
+
+func tenantStack(authn ...ssdlokiv1.AuthenticationSpec) *ssdlokiv1.SsdLoki {
+	return &ssdlokiv1.SsdLoki{
+		ObjectMeta: metav1.ObjectMeta{Name: "loki", Namespace: "ns"},
+		Spec: ssdlokiv1.SsdLokiSpec{
+			Gateway: &ssdlokiv1.GatewaySpec{
+				Enabled: true,
+				Tenants: &ssdlokiv1.TenantsSpec{Authentication: authn},
+			},
+		},
+	}
+}
+
+func oidcTenant() ssdlokiv1.AuthenticationSpec {
+	return ssdlokiv1.AuthenticationSpec{
+		TenantName: "team-a",
+		TenantID:   "team-a",
+		OIDC: &ssdlokiv1.OIDCSpec{
+			Secret:    &ssdlokiv1.TenantSecretSpec{Name: "team-a-oidc"},
+			IssuerURL: "https://dex.example.com",
+			IssuerCA:  &ssdlokiv1.CASpec{CA: "team-a-ca"},
+		},
+	}
+}
+
+func TestBuildTenantSecrets(t *testing.T) {
+	g := NewWithT(t)
+
+	k := fake.NewClientBuilder().WithObjects(
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "team-a-oidc", Namespace: "ns"},
+			Data: map[string][]byte{
+				KeyClientID:     []byte("id"),
+				KeyClientSecret: []byte("secret"),
+			},
+		},
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "team-a-ca", Namespace: "ns"},
+			Data:       map[string]string{"service-ca.crt": "pem"},
+		},
+	).Build()
+
+	secrets, err := BuildTenantSecrets(context.Background(), k, tenantStack(oidcTenant()))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(secrets).To(HaveLen(1))
+	g.Expect(secrets[0].TenantName).To(Equal("team-a"))
+	g.Expect(secrets[0].OIDCSecret.ClientID).To(Equal("id"))
+	g.Expect(secrets[0].OIDCSecret.ClientSecret).To(Equal("secret"))
+}
+
+func TestBuildTenantSecrets_DegradedReasons(t *testing.T) {
+	oidcSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "team-a-oidc", Namespace: "ns"},
+		Data:       map[string][]byte{KeyClientID: []byte("id")},
+	}
+	ca := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "team-a-ca", Namespace: "ns"},
+		Data:       map[string]string{"service-ca.crt": "pem"},
+	}
+
+	tt := []struct {
+		desc       string
+		objs       []client.Object
+		wantReason ssdlokiv1.SsdLokiConditionReason
+	}{
+		{
+			desc:       "missing oidc secret",
+			objs:       []client.Object{ca},
+			wantReason: ssdlokiv1.ReasonMissingGatewayTenantSecret,
+		},
+		{
+			desc: "oidc secret without client id",
+			objs: []client.Object{
+				ca,
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "team-a-oidc", Namespace: "ns"},
+					Data:       map[string][]byte{KeyClientSecret: []byte("secret")},
+				},
+			},
+			wantReason: ssdlokiv1.ReasonInvalidGatewayTenantSecret,
+		},
+		{
+			desc:       "missing ca configmap",
+			objs:       []client.Object{oidcSecret},
+			wantReason: ssdlokiv1.ReasonMissingGatewayTenantSecret,
+		},
+		{
+			desc: "ca configmap without key",
+			objs: []client.Object{
+				oidcSecret,
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Name: "team-a-ca", Namespace: "ns"},
+					Data:       map[string]string{"ca.crt": "pem"},
+				},
+			},
+			wantReason: ssdlokiv1.ReasonInvalidGatewayTenantSecret,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.desc, func(t *testing.T) {
+			g := NewWithT(t)
+
+			k := fake.NewClientBuilder().WithObjects(tc.objs...).Build()
+
+			_, err := BuildTenantSecrets(context.Background(), k, tenantStack(oidcTenant()))
+
+			var degraded *status.DegradedError
+			g.Expect(errors.As(err, &degraded)).To(BeTrue())
+			g.Expect(degraded.Reason).To(Equal(tc.wantReason))
+		})
+	}
+}
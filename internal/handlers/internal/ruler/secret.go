@@ -0,0 +1,76 @@
+package ruler
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ViaQ/logerr/kverrors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ssdlokiv1 "github.com/ssd-loki/loki-operator/api/v1"
+	"github.com/ssd-loki/loki-operator/internal/manifests"
+	"github.com/ssd-loki/loki-operator/internal/status"
+)
+
+// GetSecret reads the authorization Secret of the ruler remote write. It returns
+// nil if the remote write uses no authorization. Missing Secrets and fields are
+// returned as a *status.DegradedError.
+func GetSecret(ctx context.Context, k client.Client, stack *ssdlokiv1.SsdLoki) (*manifests.RulerSecret, error) {
+	name := manifests.RulerSecretName(stack)
+	if name == "" {
+		return nil, nil
+	}
+
+	var s corev1.Secret
+	key := client.ObjectKey{Name: name, Namespace: stack.Namespace}
+	if err := k.Get(ctx, key, &s); err != nil {
+		if apierrors.IsNotFound(err) {
+			// The secret watch triggers a reconcile once the secret is created.
+			return nil, &status.DegradedError{
+				Message: fmt.Sprintf("Missing ruler remote write secret %q", name),
+				Reason:  ssdlokiv1.ReasonMissingRulerSecret,
+				Requeue: false,
+			}
+		}
+		return nil, kverrors.Wrap(err, "failed to lookup ruler remote write secret", "name", key)
+	}
+
+	return extractSecret(&s, stack.Spec.Ruler.RemoteWrite.Client.AuthorizationType)
+}
+
+func extractSecret(s *corev1.Secret, authType ssdlokiv1.RemoteWriteAuthType) (*manifests.RulerSecret, error) {
+	var fields []string
+	switch authType {
+	case ssdlokiv1.RemoteWriteAuthBasic:
+		fields = []string{manifests.RulerSecretUsernameKey, manifests.RulerSecretPasswordKey}
+	case ssdlokiv1.RemoteWriteAuthBearer:
+		fields = []string{manifests.RulerSecretBearerTokenKey}
+	}
+
+	for _, field := range fields {
+		if len(s.Data[field]) == 0 {
+			return nil, &status.DegradedError{
+				Message: fmt.Sprintf("Invalid ruler remote write secret %q: missing secret field %q", s.Name, field),
+				Reason:  ssdlokiv1.ReasonInvalidRulerSecret,
+				Requeue: false,
+			}
+		}
+	}
+
+	return &manifests.RulerSecret{
+		Username:    string(s.Data[manifests.RulerSecretUsernameKey]),
+		Password:    string(s.Data[manifests.RulerSecretPasswordKey]),
+		BearerToken: string(s.Data[manifests.RulerSecretBearerTokenKey]),
+	}, nil
+}
+
+// SecretNames returns the name of the authorization Secret of the ruler remote
+// write, if any.
+func SecretNames(stack *ssdlokiv1.SsdLoki) []string {
+	if name := manifests.RulerSecretName(stack); name != "" {
+		return []string{name}
+	}
+	return nil
+}
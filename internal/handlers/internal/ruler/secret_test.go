@@ -0,0 +1,111 @@
+package ruler
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	ssdlokiv1 "github.com/ssd-loki/loki-operator/api/v1"
+	"github.com/ssd-loki/loki-operator/internal/manifests"
+	"github.com/ssd-loki/loki-operator/internal/status"
+)
+
+func remoteWriteStack(authType ssdlokiv1.RemoteWriteAuthType) *ssdlokiv1.SsdLoki {
+	return &ssdlokiv1.SsdLoki{
+		ObjectMeta: metav1.ObjectMeta{Name: "loki", Namespace: "ns"},
+		Spec: ssdlokiv1.SsdLokiSpec{
+			Ruler: &ssdlokiv1.RulerConfig{
+				RemoteWrite: &ssdlokiv1.RemoteWriteSpec{
+					Enabled: true,
+					Client: ssdlokiv1.RemoteWriteClientSpec{
+						Name:                    "prometheus",
+						URL:                     "http://prometheus.monitoring.svc:9090/api/v1/write",
+						AuthorizationType:       authType,
+						AuthorizationSecretName: "remote-write",
+					},
+				},
+			},
+		},
+	}
+}
+
+func remoteWriteSecret(data map[string]string) *corev1.Secret {
+	s := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "remote-write", Namespace: "ns"},
+		Data:       map[string][]byte{},
+	}
+	for k, v := range data {
+		s.Data[k] = []byte(v)
+	}
+	return s
+}
+
+func TestGetSecret(t *testing.T) {
+	g := NewWithT(t)
+
+	k := fake.NewClientBuilder().WithObjects(remoteWriteSecret(map[string]string{
+		"username": "loki",
+		"password": "secret",
+	})).Build()
+	s, err := GetSecret(context.Background(), k, remoteWriteStack(ssdlokiv1.RemoteWriteAuthBasic))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(s).To(Equal(&manifests.RulerSecret{Username: "loki", Password: "secret"}))
+
+	k = fake.NewClientBuilder().WithObjects(remoteWriteSecret(map[string]string{
+		"bearer_token": "token",
+	})).Build()
+	s, err = GetSecret(context.Background(), k, remoteWriteStack(ssdlokiv1.RemoteWriteAuthBearer))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(s).To(Equal(&manifests.RulerSecret{BearerToken: "token"}))
+
+	s, err = GetSecret(context.Background(), fake.NewClientBuilder().Build(), remoteWriteStack(""))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(s).To(BeNil())
+}
+
+func TestGetSecret_DegradedReasons(t *testing.T) {
+	tt := []struct {
+		desc       string
+		authType   ssdlokiv1.RemoteWriteAuthType
+		objs       []client.Object
+		wantReason ssdlokiv1.SsdLokiConditionReason
+	}{
+		{
+			desc:       "missing secret",
+			authType:   ssdlokiv1.RemoteWriteAuthBasic,
+			wantReason: ssdlokiv1.ReasonMissingRulerSecret,
+		},
+		{
+			desc:       "basic auth without password",
+			authType:   ssdlokiv1.RemoteWriteAuthBasic,
+			objs:       []client.Object{remoteWriteSecret(map[string]string{"username": "loki"})},
+			wantReason: ssdlokiv1.ReasonInvalidRulerSecret,
+		},
+		{
+			desc:       "bearer auth without token",
+			authType:   ssdlokiv1.RemoteWriteAuthBearer,
+			objs:       []client.Object{remoteWriteSecret(map[string]string{"username": "loki", "password": "secret"})},
+			wantReason: ssdlokiv1.ReasonInvalidRulerSecret,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.desc, func(t *testing.T) {
+			g := NewWithT(t)
+
+			k := fake.NewClientBuilder().WithObjects(tc.objs...).Build()
+
+			_, err := GetSecret(context.Background(), k, remoteWriteStack(tc.authType))
+
+			var degraded *status.DegradedError
+			g.Expect(errors.As(err, &degraded)).To(BeTrue())
+			g.Expect(degraded.Reason).To(Equal(tc.wantReason))
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package rules
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/ViaQ/logerr/kverrors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ssdlokiv1 "github.com/ssd-loki/loki-operator/api/v1"
+	"github.com/ssd-loki/loki-operator/internal/manifests"
+	"github.com/ssd-loki/loki-operator/internal/status"
+)
+
+// List returns the AlertingRules and RecordingRules selected by the stack. Rules
+// are discovered in the namespaces matching the namespace selector, or in the
+// namespace of the stack if none is set. An invalid selector is returned as a
+// *status.DegradedError.
+func List(ctx context.Context, k client.Client, stack *ssdlokiv1.SsdLoki) ([]ssdlokiv1.AlertingRule, []ssdlokiv1.RecordingRule, error) {
+	if !manifests.RulesEnabled(stack) {
+		return nil, nil, nil
+	}
+
+	selector, err := selectorFor(stack.Spec.Rules.Selector)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	namespaces, err := namespaces(ctx, k, stack)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		alerting  []ssdlokiv1.AlertingRule
+		recording []ssdlokiv1.RecordingRule
+	)
+	for _, ns := range namespaces {
+		opts := []client.ListOption{
+			client.InNamespace(ns),
+			client.MatchingLabelsSelector{Selector: selector},
+		}
+
+		var ar ssdlokiv1.AlertingRuleList
+		if err := k.List(ctx, &ar, opts...); err != nil {
+			return nil, nil, kverrors.Wrap(err, "failed to list alerting rules", "namespace", ns)
+		}
+		alerting = append(alerting, ar.Items...)
+
+		var rr ssdlokiv1.RecordingRuleList
+		if err := k.List(ctx, &rr, opts...); err != nil {
+			return nil, nil, kverrors.Wrap(err, "failed to list recording rules", "namespace", ns)
+		}
+		recording = append(recording, rr.Items...)
+	}
+
+	return alerting, recording, nil
+}
+
+// Selects reports whether the stack loads rules with the given labels from the
+// given namespace. Namespaces are matched by name only, as the labels of the
+// namespace are not at hand.
+func Selects(stack *ssdlokiv1.SsdLoki, namespace string, ruleLabels map[string]string) bool {
+	if !manifests.RulesEnabled(stack) {
+		return false
+	}
+	if stack.Spec.Rules.NamespaceSelector == nil && namespace != stack.Namespace {
+		return false
+	}
+
+	selector, err := selectorFor(stack.Spec.Rules.Selector)
+	if err != nil {
+		return false
+	}
+	return selector.Matches(labels.Set(ruleLabels))
+}
+
+// ObsoleteConfigMaps returns the rules ConfigMaps of the stack that are not part
+// of the desired objects, e.g. after rules were removed or disabled.
+func ObsoleteConfigMaps(ctx context.Context, k client.Client, stack *ssdlokiv1.SsdLoki, desired []client.Object) ([]client.Object, error) {
+	var cms corev1.ConfigMapList
+	if err := k.List(ctx, &cms,
+		client.InNamespace(stack.Namespace),
+		client.MatchingLabels(manifests.RulesConfigMapLabels(stack.Name)),
+	); err != nil {
+		return nil, kverrors.Wrap(err, "failed to list rules configmaps", "namespace", stack.Namespace)
+	}
+
+	var objs []client.Object
+	for i := range cms.Items {
+		cm := &cms.Items[i]
+		if slices.ContainsFunc(desired, func(obj client.Object) bool {
+			_, ok := obj.(*corev1.ConfigMap)
+			return ok && obj.GetName() == cm.Name
+		}) {
+			continue
+		}
+		objs = append(objs, cm)
+	}
+	return objs, nil
+}
+
+func namespaces(ctx context.Context, k client.Client, stack *ssdlokiv1.SsdLoki) ([]string, error) {
+	if stack.Spec.Rules.NamespaceSelector == nil {
+		return []string{stack.Namespace}, nil
+	}
+
+	selector, err := selectorFor(stack.Spec.Rules.NamespaceSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	var nsl corev1.NamespaceList
+	if err := k.List(ctx, &nsl, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, kverrors.Wrap(err, "failed to list namespaces")
+	}
+
+	names := make([]string, 0, len(nsl.Items))
+	for _, ns := range nsl.Items {
+		names = append(names, ns.Name)
+	}
+	return names, nil
+}
+
+func selectorFor(ls *metav1.LabelSelector) (labels.Selector, error) {
+	if ls == nil {
+		return labels.Everything(), nil
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(ls)
+	if err != nil {
+		return nil, &status.DegradedError{
+			Message: fmt.Sprintf("Invalid rules selector: %s", err),
+			Reason:  ssdlokiv1.ReasonInvalidRulesSelector,
+			Requeue: false,
+		}
+	}
+	return selector, nil
+}
@@ -0,0 +1,176 @@
+package rules
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	ssdlokiv1 "github.com/ssd-loki/loki-operator/api/v1"
+	"github.com/ssd-loki/loki-operator/internal/manifests"
+	"github.com/ssd-loki/loki-operator/internal/status"
+)
+
+func newTestClient(t *testing.T, objs ...client.Object) client.Client {
+	s := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	if err := ssdlokiv1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+
+	return fake.NewClientBuilder().WithScheme(s).WithObjects(objs...).Build()
+}
+
+func rulesStack(rules *ssdlokiv1.RulesSpec) *ssdlokiv1.SsdLoki {
+	return &ssdlokiv1.SsdLoki{
+		ObjectMeta: metav1.ObjectMeta{Name: "loki", Namespace: "ns"},
+		Spec:       ssdlokiv1.SsdLokiSpec{Rules: rules},
+	}
+}
+
+func namespace(name string, l map[string]string) *corev1.Namespace {
+	return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: l}}
+}
+
+func alertingRule(namespace, name string, l map[string]string) *ssdlokiv1.AlertingRule {
+	return &ssdlokiv1.AlertingRule{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: l},
+		Spec:       ssdlokiv1.AlertingRuleSpec{TenantID: "fake"},
+	}
+}
+
+func recordingRule(namespace, name string, l map[string]string) *ssdlokiv1.RecordingRule {
+	return &ssdlokiv1.RecordingRule{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: l},
+		Spec:       ssdlokiv1.RecordingRuleSpec{TenantID: "fake"},
+	}
+}
+
+func TestList(t *testing.T) {
+	objs := []client.Object{
+		namespace("ns", nil),
+		namespace("team-a", map[string]string{"rules": "loki"}),
+		namespace("team-b", nil),
+		alertingRule("ns", "local", map[string]string{"team": "a"}),
+		alertingRule("team-a", "selected", map[string]string{"team": "a"}),
+		alertingRule("team-a", "other-team", map[string]string{"team": "b"}),
+		alertingRule("team-b", "unselected-namespace", map[string]string{"team": "a"}),
+		recordingRule("team-a", "rates", map[string]string{"team": "a"}),
+	}
+	teamA := &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}}
+
+	tt := []struct {
+		desc          string
+		rules         *ssdlokiv1.RulesSpec
+		wantAlerting  []string
+		wantRecording []string
+	}{
+		{
+			desc:  "disabled",
+			rules: &ssdlokiv1.RulesSpec{Selector: teamA},
+		},
+		{
+			desc:         "namespace of the stack",
+			rules:        &ssdlokiv1.RulesSpec{Enabled: true},
+			wantAlerting: []string{"local"},
+		},
+		{
+			desc: "namespace selector",
+			rules: &ssdlokiv1.RulesSpec{
+				Enabled:           true,
+				Selector:          teamA,
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"rules": "loki"}},
+			},
+			wantAlerting:  []string{"selected"},
+			wantRecording: []string{"rates"},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.desc, func(t *testing.T) {
+			g := NewWithT(t)
+
+			alerting, recording, err := List(context.Background(), newTestClient(t, objs...), rulesStack(tc.rules))
+			g.Expect(err).NotTo(HaveOccurred())
+
+			var names []string
+			for _, r := range alerting {
+				names = append(names, r.Name)
+			}
+			g.Expect(names).To(ConsistOf(tc.wantAlerting))
+
+			names = nil
+			for _, r := range recording {
+				names = append(names, r.Name)
+			}
+			g.Expect(names).To(ConsistOf(tc.wantRecording))
+		})
+	}
+}
+
+func TestList_InvalidSelector(t *testing.T) {
+	g := NewWithT(t)
+
+	stack := rulesStack(&ssdlokiv1.RulesSpec{
+		Enabled: true,
+		Selector: &metav1.LabelSelector{
+			MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "team", Operator: "Matches"}},
+		},
+	})
+
+	_, _, err := List(context.Background(), newTestClient(t), stack)
+
+	var degraded *status.DegradedError
+	g.Expect(errors.As(err, &degraded)).To(BeTrue())
+	g.Expect(degraded.Reason).To(Equal(ssdlokiv1.ReasonInvalidRulesSelector))
+}
+
+func TestSelects(t *testing.T) {
+	g := NewWithT(t)
+
+	stack := rulesStack(&ssdlokiv1.RulesSpec{
+		Enabled:  true,
+		Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}},
+	})
+	g.Expect(Selects(stack, "ns", map[string]string{"team": "a"})).To(BeTrue())
+	g.Expect(Selects(stack, "ns", map[string]string{"team": "b"})).To(BeFalse())
+	g.Expect(Selects(stack, "other", map[string]string{"team": "a"})).To(BeFalse())
+
+	stack.Spec.Rules.NamespaceSelector = &metav1.LabelSelector{}
+	g.Expect(Selects(stack, "other", map[string]string{"team": "a"})).To(BeTrue())
+
+	stack.Spec.Rules.Enabled = false
+	g.Expect(Selects(stack, "ns", map[string]string{"team": "a"})).To(BeFalse())
+}
+
+func TestObsoleteConfigMaps(t *testing.T) {
+	g := NewWithT(t)
+
+	shard := func(name string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "ns",
+				Labels:    manifests.RulesConfigMapLabels("loki"),
+			},
+		}
+	}
+	k := newTestClient(t,
+		shard("loki-rules-0"),
+		shard("loki-rules-1"),
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "unrelated", Namespace: "ns"}},
+	)
+
+	objs, err := ObsoleteConfigMaps(context.Background(), k, rulesStack(nil), []client.Object{shard("loki-rules-0")})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(objs).To(ConsistOf(HaveField("ObjectMeta.Name", "loki-rules-1")))
+}
@@ -0,0 +1,45 @@
+package storage
+
+import (
+	"crypto/sha1"
+	"fmt"
+	"sort"
+
+	"github.com/ViaQ/logerr/kverrors"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/ssd-loki/loki-operator/internal/manifests/storage"
+)
+
+func extractSecret(s *corev1.Secret, t storage.ObjectStorageType) (storage.Options, error) {
+	for _, key := range storage.SecretKeys(t) {
+		if len(s.Data[key]) == 0 {
+			return storage.Options{}, kverrors.New(fmt.Sprintf("missing secret field %q", key), "secret", s.Name, "type", t)
+		}
+	}
+
+	return storage.Options{
+		SharedStore: t,
+		SecretName:  s.Name,
+		SecretSHA1:  hashSecretData(s),
+	}, nil
+}
+
+// hashSecretData returns a stable hash of all data in the Secret.
+func hashSecretData(s *corev1.Secret) string {
+	keys := make([]string, 0, len(s.Data))
+	for k := range s.Data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	h := sha1.New()
+	for _, k := range keys {
+		_, _ = h.Write([]byte(k))
+		_, _ = h.Write([]byte{0})
+		_, _ = h.Write(s.Data[k])
+		_, _ = h.Write([]byte{0})
+	}
+
+	return fmt.Sprintf("%x", h.Sum(nil))
+}
@@ -0,0 +1,120 @@
+package storage
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/ssd-loki/loki-operator/internal/manifests/storage"
+)
+
+func TestExtractSecret(t *testing.T) {
+	tt := []struct {
+		desc        string
+		storageType storage.ObjectStorageType
+		data        map[string][]byte
+		wantErr     bool
+	}{
+		{
+			desc:        "s3 missing access_key_id",
+			storageType: storage.ObjectStorageTypeS3,
+			data:        map[string][]byte{"access_key_secret": []byte("secret")},
+			wantErr:     true,
+		},
+		{
+			desc:        "s3 empty access_key_secret",
+			storageType: storage.ObjectStorageTypeS3,
+			data: map[string][]byte{
+				"access_key_id":     []byte("id"),
+				"access_key_secret": {},
+			},
+			wantErr: true,
+		},
+		{
+			desc:        "s3 all keys set",
+			storageType: storage.ObjectStorageTypeS3,
+			data: map[string][]byte{
+				"access_key_id":     []byte("id"),
+				"access_key_secret": []byte("secret"),
+			},
+		},
+		{
+			desc:        "gcs missing key.json",
+			storageType: storage.ObjectStorageTypeGCS,
+			data:        map[string][]byte{"access_key_id": []byte("id")},
+			wantErr:     true,
+		},
+		{
+			desc:        "gcs all keys set",
+			storageType: storage.ObjectStorageTypeGCS,
+			data:        map[string][]byte{"key.json": []byte("{}")},
+		},
+		{
+			desc:        "azure missing account_key",
+			storageType: storage.ObjectStorageTypeAzure,
+			data:        map[string][]byte{"account_name": []byte("name")},
+			wantErr:     true,
+		},
+		{
+			desc:        "azure all keys set",
+			storageType: storage.ObjectStorageTypeAzure,
+			data: map[string][]byte{
+				"account_name": []byte("name"),
+				"account_key":  []byte("key"),
+			},
+		},
+		{
+			desc:        "swift missing password",
+			storageType: storage.ObjectStorageTypeSwift,
+			data:        map[string][]byte{"username": []byte("user")},
+			wantErr:     true,
+		},
+		{
+			desc:        "swift all keys set",
+			storageType: storage.ObjectStorageTypeSwift,
+			data: map[string][]byte{
+				"username": []byte("user"),
+				"password": []byte("pass"),
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.desc, func(t *testing.T) {
+			g := NewWithT(t)
+
+			s := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "storage"},
+				Data:       tc.data,
+			}
+
+			opts, err := extractSecret(s, tc.storageType)
+			if tc.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(opts.SharedStore).To(Equal(tc.storageType))
+			g.Expect(opts.SecretName).To(Equal("storage"))
+			g.Expect(opts.SecretSHA1).NotTo(BeEmpty())
+		})
+	}
+}
+
+func TestHashSecretData_ChangesWithData(t *testing.T) {
+	g := NewWithT(t)
+
+	s := &corev1.Secret{
+		Data: map[string][]byte{
+			"access_key_id":     []byte("id"),
+			"access_key_secret": []byte("secret"),
+		},
+	}
+	before := hashSecretData(s)
+	g.Expect(hashSecretData(s)).To(Equal(before))
+
+	s.Data["access_key_secret"] = []byte("rotated")
+	g.Expect(hashSecretData(s)).NotTo(Equal(before))
+}
@@ -0,0 +1,95 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ViaQ/logerr/kverrors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ssdlokiv1 "github.com/ssd-loki/loki-operator/api/v1"
+	"github.com/ssd-loki/loki-operator/internal/manifests/storage"
+	"github.com/ssd-loki/loki-operator/internal/status"
+)
+
+// BuildOptions returns the object storage options of the stack, reading and
+// validating the credentials Secret it references. Problems the user has to fix in
+// the spec or the Secret are returned as a *status.DegradedError.
+func BuildOptions(ctx context.Context, k client.Client, stack *ssdlokiv1.SsdLoki) (storage.Options, error) {
+	var common *ssdlokiv1.CommonStorage
+	if stack.Spec.Common != nil {
+		common = stack.Spec.Common.Storage
+	}
+
+	t, err := storage.TypeOf(common)
+	if err != nil {
+		return storage.Options{}, &status.DegradedError{
+			Message: fmt.Sprintf("Invalid object storage config: %s", err),
+			Reason:  ssdlokiv1.ReasonInvalidObjectStorageConfig,
+			Requeue: false,
+		}
+	}
+
+	ref := secretRef(stack)
+	if ref == nil {
+		if storage.SecretRequired(t) {
+			return storage.Options{}, &status.DegradedError{
+				Message: fmt.Sprintf("Object storage %s requires a credentials secret", t),
+				Reason:  ssdlokiv1.ReasonInvalidObjectStorageConfig,
+				Requeue: false,
+			}
+		}
+		return storage.Options{SharedStore: t}, nil
+	}
+
+	if t == storage.ObjectStorageTypeFilesystem {
+		return storage.Options{}, &status.DegradedError{
+			Message: "Filesystem storage does not use a credentials secret",
+			Reason:  ssdlokiv1.ReasonInvalidObjectStorageConfig,
+			Requeue: false,
+		}
+	}
+
+	var s corev1.Secret
+	key := client.ObjectKey{Name: ref.Name, Namespace: stack.Namespace}
+	if err := k.Get(ctx, key, &s); err != nil {
+		if apierrors.IsNotFound(err) {
+			// The secret watch triggers a reconcile once the secret is created.
+			return storage.Options{}, &status.DegradedError{
+				Message: fmt.Sprintf("Missing object storage secret %q", ref.Name),
+				Reason:  ssdlokiv1.ReasonMissingObjectStorageSecret,
+				Requeue: false,
+			}
+		}
+		return storage.Options{}, kverrors.Wrap(err, "failed to lookup storage secret", "name", key)
+	}
+
+	opts, err := extractSecret(&s, t)
+	if err != nil {
+		return storage.Options{}, &status.DegradedError{
+			Message: fmt.Sprintf("Invalid object storage secret contents: %s", err),
+			Reason:  ssdlokiv1.ReasonInvalidObjectStorageSecret,
+			Requeue: false,
+		}
+	}
+
+	return opts, nil
+}
+
+// SecretName returns the name of the storage Secret referenced by the stack, if any.
+func SecretName(stack *ssdlokiv1.SsdLoki) string {
+	if ref := secretRef(stack); ref != nil {
+		return ref.Name
+	}
+	return ""
+}
+
+func secretRef(stack *ssdlokiv1.SsdLoki) *ssdlokiv1.ObjectStorageSecretSpec {
+	common := stack.Spec.Common
+	if common == nil || common.Storage == nil {
+		return nil
+	}
+	return common.Storage.Secret
+}
@@ -0,0 +1,78 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	ssdlokiv1 "github.com/ssd-loki/loki-operator/api/v1"
+	"github.com/ssd-loki/loki-operator/internal/status"
+)
+
+func TestBuildOptions_DegradedReasons(t *testing.T) {
+	s3 := &ssdlokiv1.S3Config{BucketNames: "chunks", Endpoint: "s3.example.com"}
+
+	tt := []struct {
+		desc       string
+		storage    *ssdlokiv1.CommonStorage
+		objs       []client.Object
+		wantReason ssdlokiv1.SsdLokiConditionReason
+	}{
+		{
+			desc: "more than one backend",
+			storage: &ssdlokiv1.CommonStorage{
+				S3:  s3,
+				GCS: &ssdlokiv1.GCSConfig{BucketName: "chunks"},
+			},
+			wantReason: ssdlokiv1.ReasonInvalidObjectStorageConfig,
+		},
+		{
+			desc: "missing secret",
+			storage: &ssdlokiv1.CommonStorage{
+				S3:     s3,
+				Secret: &ssdlokiv1.ObjectStorageSecretSpec{Name: "creds"},
+			},
+			wantReason: ssdlokiv1.ReasonMissingObjectStorageSecret,
+		},
+		{
+			desc: "secret without required keys",
+			storage: &ssdlokiv1.CommonStorage{
+				S3:     s3,
+				Secret: &ssdlokiv1.ObjectStorageSecretSpec{Name: "creds"},
+			},
+			objs: []client.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "creds", Namespace: "ns"},
+					Data:       map[string][]byte{"access_key_id": []byte("id")},
+				},
+			},
+			wantReason: ssdlokiv1.ReasonInvalidObjectStorageSecret,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.desc, func(t *testing.T) {
+			g := NewWithT(t)
+
+			k := fake.NewClientBuilder().WithObjects(tc.objs...).Build()
+			stack := &ssdlokiv1.SsdLoki{
+				ObjectMeta: metav1.ObjectMeta{Name: "loki", Namespace: "ns"},
+				Spec: ssdlokiv1.SsdLokiSpec{
+					Common: &ssdlokiv1.CommonConfig{Storage: tc.storage},
+				},
+			}
+
+			_, err := BuildOptions(context.Background(), k, stack)
+
+			var degraded *status.DegradedError
+			g.Expect(errors.As(err, &degraded)).To(BeTrue())
+			g.Expect(degraded.Reason).To(Equal(tc.wantReason))
+		})
+	}
+}
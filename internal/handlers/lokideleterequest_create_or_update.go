@@ -0,0 +1,199 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/ViaQ/logerr/kverrors"
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	ssdlokiv1 "github.com/ssd-loki/loki-operator/api/v1"
+	"github.com/ssd-loki/loki-operator/internal/handlers/internal/compactor"
+	"github.com/ssd-loki/loki-operator/internal/lokiclient"
+	"github.com/ssd-loki/loki-operator/internal/manifests"
+)
+
+// deleteRequestFinalizer keeps a LokiDeleteRequest until its cancellation has
+// been submitted to the compactor.
+const deleteRequestFinalizer = "ssd-loki.ssd-loki.com/delete-request"
+
+// CreateOrUpdateLokiDeleteRequest submits a LokiDeleteRequest to the compactor
+// of its stack and refreshes its status from the delete requests listed by the
+// compactor. Deleting the object cancels the request unless the compactor has
+// already started processing it. It reports whether the request is still in
+// progress, so that the caller polls the compactor again.
+func CreateOrUpdateLokiDeleteRequest(
+	ctx context.Context,
+	log logr.Logger,
+	req ctrl.Request,
+	k client.Client,
+	hc *http.Client,
+) (bool, error) {
+	ll := log.WithValues("lokideleterequest", req.NamespacedName, "event", "createOrUpdate")
+
+	var dr ssdlokiv1.LokiDeleteRequest
+	if err := k.Get(ctx, req.NamespacedName, &dr); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, kverrors.Wrap(err, "failed to lookup lokideleterequest", "name", req.NamespacedName)
+	}
+
+	if !dr.DeletionTimestamp.IsZero() {
+		return false, cancelLokiDeleteRequest(ctx, ll, k, hc, &dr)
+	}
+
+	if controllerutil.AddFinalizer(&dr, deleteRequestFinalizer) {
+		if err := k.Update(ctx, &dr); err != nil {
+			return false, kverrors.Wrap(err, "failed to add finalizer", "name", req.NamespacedName)
+		}
+	}
+
+	if dr.Status.Phase == ssdlokiv1.DeleteRequestProcessed {
+		return false, nil
+	}
+
+	stack, err := deleteRequestStack(ctx, k, &dr)
+	if err != nil {
+		return false, err
+	}
+	if stack == nil {
+		msg := fmt.Sprintf("SsdLoki %q not found", dr.Spec.StackName)
+		return true, updateLokiDeleteRequestStatus(ctx, k, &dr, ssdlokiv1.DeleteRequestPending, dr.Status.RequestID, msg)
+	}
+	if stack.Spec.Retention == nil || !stack.Spec.Retention.Enabled {
+		// The compactor only keeps delete requests with retention enabled. The
+		// request is not retried, as enabling retention does not requeue it.
+		msg := fmt.Sprintf("Retention is not enabled on SsdLoki %q", stack.Name)
+		return false, updateLokiDeleteRequestStatus(ctx, k, &dr, ssdlokiv1.DeleteRequestFailed, dr.Status.RequestID, msg)
+	}
+
+	hc, err = lokiclient.ForStack(ctx, k, hc, stack, manifests.BackendServerName(stack))
+	if err != nil {
+		return false, err
+	}
+	endpoint := manifests.CompactorDeleteURL(stack)
+
+	// The compactor assigns no ID on submission, so the request is looked up by
+	// its query and time range. Listing first keeps a failed status update from
+	// submitting the request twice.
+	match, err := findDeleteRequest(ctx, hc, endpoint, &dr)
+	if err != nil {
+		return false, err
+	}
+	if match == nil {
+		if dr.Status.RequestID != "" {
+			msg := fmt.Sprintf("Delete request %q is no longer known by the compactor", dr.Status.RequestID)
+			return false, updateLokiDeleteRequestStatus(ctx, k, &dr, ssdlokiv1.DeleteRequestFailed, dr.Status.RequestID, msg)
+		}
+
+		s := dr.Spec
+		if err := compactor.Submit(ctx, hc, endpoint, s.TenantID, s.Query, s.Start.Time, s.End.Time); err != nil {
+			return false, err
+		}
+		ll.Info("delete request submitted to the compactor")
+
+		match, err = findDeleteRequest(ctx, hc, endpoint, &dr)
+		if err != nil {
+			return false, err
+		}
+		if match == nil {
+			return true, updateLokiDeleteRequestStatus(ctx, k, &dr, ssdlokiv1.DeleteRequestPending, "", "Waiting for the compactor to list the request")
+		}
+	}
+
+	if match.Status == compactor.StatusProcessed {
+		return false, updateLokiDeleteRequestStatus(ctx, k, &dr, ssdlokiv1.DeleteRequestProcessed, match.RequestID, "")
+	}
+	msg := fmt.Sprintf("Compactor reports the request as %s", match.Status)
+	return true, updateLokiDeleteRequestStatus(ctx, k, &dr, ssdlokiv1.DeleteRequestReceived, match.RequestID, msg)
+}
+
+// cancelLokiDeleteRequest cancels the delete request of a deleted
+// LokiDeleteRequest and releases the object. Requests the compactor is already
+// processing cannot be cancelled and are left to complete.
+func cancelLokiDeleteRequest(ctx context.Context, ll logr.Logger, k client.Client, hc *http.Client, dr *ssdlokiv1.LokiDeleteRequest) error {
+	if !controllerutil.ContainsFinalizer(dr, deleteRequestFinalizer) {
+		return nil
+	}
+
+	if dr.Status.RequestID != "" && dr.Status.Phase != ssdlokiv1.DeleteRequestProcessed {
+		stack, err := deleteRequestStack(ctx, k, dr)
+		if err != nil {
+			return err
+		}
+		if stack != nil {
+			hc, err = lokiclient.ForStack(ctx, k, hc, stack, manifests.BackendServerName(stack))
+			if err != nil {
+				return err
+			}
+			err = compactor.Cancel(ctx, hc, manifests.CompactorDeleteURL(stack), dr.Spec.TenantID, dr.Status.RequestID)
+			switch {
+			case errors.Is(err, compactor.ErrCancelNotAllowed):
+				ll.Info("delete request is already processed by the compactor and cannot be cancelled", "request_id", dr.Status.RequestID)
+			case err != nil:
+				return err
+			default:
+				ll.Info("delete request cancelled", "request_id", dr.Status.RequestID)
+			}
+		}
+	}
+
+	controllerutil.RemoveFinalizer(dr, deleteRequestFinalizer)
+	if err := k.Update(ctx, dr); err != nil {
+		return kverrors.Wrap(err, "failed to remove finalizer", "name", client.ObjectKeyFromObject(dr))
+	}
+	return nil
+}
+
+// deleteRequestStack returns the stack of a LokiDeleteRequest, or nil if it does not exist.
+func deleteRequestStack(ctx context.Context, k client.Client, dr *ssdlokiv1.LokiDeleteRequest) (*ssdlokiv1.SsdLoki, error) {
+	var stack ssdlokiv1.SsdLoki
+	key := client.ObjectKey{Name: dr.Spec.StackName, Namespace: dr.Namespace}
+	if err := k.Get(ctx, key, &stack); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, kverrors.Wrap(err, "failed to lookup ssdloki", "name", key)
+	}
+	return &stack, nil
+}
+
+// findDeleteRequest returns the delete request listed by the compactor that
+// matches the LokiDeleteRequest and, once known, has the ID recorded in its status.
+func findDeleteRequest(ctx context.Context, hc *http.Client, endpoint string, dr *ssdlokiv1.LokiDeleteRequest) (*compactor.DeleteRequest, error) {
+	requests, err := compactor.List(ctx, hc, endpoint, dr.Spec.TenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range requests {
+		r := &requests[i]
+		if !r.Matches(dr.Spec.Query, dr.Spec.Start.Time, dr.Spec.End.Time) {
+			continue
+		}
+		if dr.Status.RequestID == "" || r.RequestID == dr.Status.RequestID {
+			return r, nil
+		}
+	}
+	return nil, nil
+}
+
+func updateLokiDeleteRequestStatus(ctx context.Context, k client.Client, dr *ssdlokiv1.LokiDeleteRequest, phase ssdlokiv1.LokiDeleteRequestPhase, id, msg string) error {
+	s := ssdlokiv1.LokiDeleteRequestStatus{Phase: phase, RequestID: id, Message: msg}
+	if dr.Status == s {
+		return nil
+	}
+
+	dr.Status = s
+	if err := k.Status().Update(ctx, dr); err != nil {
+		return kverrors.Wrap(err, "failed to update lokideleterequest status", "name", client.ObjectKeyFromObject(dr))
+	}
+	return nil
+}
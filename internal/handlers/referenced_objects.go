@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ssdlokiv1 "github.com/ssd-loki/loki-operator/api/v1"
+	"github.com/ssd-loki/loki-operator/internal/handlers/internal/alertmanager"
+	"github.com/ssd-loki/loki-operator/internal/handlers/internal/certificates"
+	"github.com/ssd-loki/loki-operator/internal/handlers/internal/gateway"
+	"github.com/ssd-loki/loki-operator/internal/handlers/internal/ruler"
+	"github.com/ssd-loki/loki-operator/internal/handlers/internal/rules"
+	"github.com/ssd-loki/loki-operator/internal/handlers/internal/storage"
+)
+
+// ReferencedSecretNames returns the names of the Secrets the stack reads: the
+// object storage credentials, the OIDC Secrets of the gateway tenants, the
+// certificates of internal TLS and the credentials of the Alertmanager clients
+// and of the ruler remote write.
+func ReferencedSecretNames(stack *ssdlokiv1.SsdLoki) []string {
+	var names []string
+	if name := storage.SecretName(stack); name != "" {
+		names = append(names, name)
+	}
+	names = append(names, gateway.SecretNames(stack)...)
+	names = append(names, certificates.SecretNames(stack)...)
+	names = append(names, alertmanager.SecretNames(stack)...)
+	names = append(names, ruler.SecretNames(stack)...)
+	return names
+}
+
+// ReferencedConfigMapNames returns the names of the CA ConfigMaps the stack
+// reads for the gateway tenants, internal TLS and the Alertmanager clients.
+func ReferencedConfigMapNames(stack *ssdlokiv1.SsdLoki) []string {
+	var names []string
+	names = append(names, gateway.ConfigMapNames(stack)...)
+	names = append(names, certificates.ConfigMapNames(stack)...)
+	names = append(names, alertmanager.ConfigMapNames(stack)...)
+	return names
+}
+
+// SelectsRule reports whether the stack loads the AlertingRule or RecordingRule.
+func SelectsRule(stack *ssdlokiv1.SsdLoki, rule client.Object) bool {
+	return rules.Selects(stack, rule.GetNamespace(), rule.GetLabels())
+}
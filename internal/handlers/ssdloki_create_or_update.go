@@ -0,0 +1,216 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ViaQ/logerr/kverrors"
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	ssdlokiv1 "github.com/ssd-loki/loki-operator/api/v1"
+	"github.com/ssd-loki/loki-operator/internal/handlers/internal/alertmanager"
+	"github.com/ssd-loki/loki-operator/internal/handlers/internal/certificates"
+	"github.com/ssd-loki/loki-operator/internal/handlers/internal/gateway"
+	"github.com/ssd-loki/loki-operator/internal/handlers/internal/ruler"
+	"github.com/ssd-loki/loki-operator/internal/handlers/internal/rules"
+	"github.com/ssd-loki/loki-operator/internal/handlers/internal/storage"
+	"github.com/ssd-loki/loki-operator/internal/manifests"
+	"github.com/ssd-loki/loki-operator/internal/status"
+	"github.com/ssd-loki/loki-operator/internal/validation"
+)
+
+// CreateOrUpdateSsdLoki handles SsdLoki create and update events. Configuration
+// problems the user has to fix are returned as a *status.DegradedError.
+func CreateOrUpdateSsdLoki(
+	ctx context.Context,
+	log logr.Logger,
+	req ctrl.Request,
+	k client.Client,
+	s *runtime.Scheme,
+) error {
+	ll := log.WithValues("ssdloki", req.NamespacedName, "event", "createOrUpdate")
+
+	var stack ssdlokiv1.SsdLoki
+	if err := k.Get(ctx, req.NamespacedName, &stack); err != nil {
+		if apierrors.IsNotFound(err) {
+			// maybe the user deleted it before we could react? Either way this isn't an issue
+			ll.Error(err, "could not find the requested ssdloki", "name", req.NamespacedName)
+			return nil
+		}
+		return kverrors.Wrap(err, "failed to lookup ssdloki", "name", req.NamespacedName)
+	}
+
+	if errs := validation.ValidateSchemas(stack.Spec.SchemaConfig, field.NewPath("spec", "schemaConfig")); len(errs) > 0 {
+		return &status.DegradedError{
+			Message: fmt.Sprintf("Invalid schema config: %s", errs.ToAggregate()),
+			Reason:  ssdlokiv1.ReasonInvalidSchemaConfig,
+			Requeue: false,
+		}
+	}
+
+	objStore, err := storage.BuildOptions(ctx, k, &stack)
+	if err != nil {
+		ll.Error(err, "failed to build object storage options")
+		return err
+	}
+
+	tenantSecrets, err := gateway.BuildTenantSecrets(ctx, k, &stack)
+	if err != nil {
+		ll.Error(err, "failed to build gateway tenant secrets")
+		return err
+	}
+
+	certificatesSHA1, err := certificates.BuildHash(ctx, k, &stack)
+	if err != nil {
+		ll.Error(err, "failed to read internal tls certificates")
+		return err
+	}
+
+	alertManagerSHA1, err := alertmanager.BuildHash(ctx, k, &stack)
+	if err != nil {
+		ll.Error(err, "failed to read alertmanager client secrets")
+		return err
+	}
+
+	rulerSecret, err := ruler.GetSecret(ctx, k, &stack)
+	if err != nil {
+		ll.Error(err, "failed to read ruler remote write secret")
+		return err
+	}
+
+	alertingRules, recordingRules, err := rules.List(ctx, k, &stack)
+	if err != nil {
+		ll.Error(err, "failed to list rules")
+		return err
+	}
+
+	opts := manifests.Options{
+		Name:          req.Name,
+		Namespace:     req.Namespace,
+		Stack:         stack,
+		ObjectStorage: objStore,
+		Tenants: manifests.Tenants{
+			Secrets: tenantSecrets,
+		},
+		CertificatesSHA1: certificatesSHA1,
+		AlertManagerSHA1: alertManagerSHA1,
+		RulerSecret:      rulerSecret,
+		AlertingRules:    alertingRules,
+		RecordingRules:   recordingRules,
+	}
+
+	ll.Info("begin building manifests")
+
+	if optErr := manifests.ApplyDefaultSettings(&opts); optErr != nil {
+		ll.Error(optErr, "failed to conform options to build settings")
+		return optErr
+	}
+
+	objects, err := manifests.BuildAll(opts)
+	if err != nil {
+		ll.Error(err, "failed to build manifests")
+		return err
+	}
+
+	ll.Info("manifests built", "count", len(objects))
+
+	var (
+		errCount     int32
+		volumeClaims []string
+	)
+
+	for _, obj := range objects {
+		l := ll.WithValues(
+			"object_name", obj.GetName(),
+			"object_kind", obj.GetObjectKind(),
+		)
+
+		if err := ctrl.SetControllerReference(&stack, obj, s); err != nil {
+			l.Error(err, "failed to set controller owner reference to resource")
+			errCount++
+			continue
+		}
+
+		desired := obj.DeepCopyObject().(client.Object)
+		mutateFn := manifests.MutateFuncFor(obj, desired)
+
+		op, err := ctrl.CreateOrUpdate(ctx, k, obj, mutateFn)
+		if err != nil {
+			l.Error(err, "failed to configure resource")
+			errCount++
+			continue
+		}
+
+		if sts, ok := obj.(*appsv1.StatefulSet); ok && manifests.VolumeClaimTemplatesChanged(sts, desired.(*appsv1.StatefulSet)) {
+			volumeClaims = append(volumeClaims, sts.Name)
+		}
+
+		msg := fmt.Sprintf("Resource has been %s", op)
+		switch op {
+		case controllerutil.OperationResultNone:
+			l.V(1).Info(msg)
+		default:
+			l.Info(msg)
+		}
+	}
+
+	obsolete := manifests.ObsoleteObjects(opts)
+	obsoleteRules, err := rules.ObsoleteConfigMaps(ctx, k, &stack, objects)
+	if err != nil {
+		ll.Error(err, "failed to lookup obsolete rules configmaps")
+		errCount++
+	}
+	obsolete = append(obsolete, obsoleteRules...)
+
+	for _, obj := range obsolete {
+		l := ll.WithValues(
+			"object_name", obj.GetName(),
+			"object_kind", fmt.Sprintf("%T", obj),
+		)
+
+		if err := k.Get(ctx, client.ObjectKeyFromObject(obj), obj); err != nil {
+			if !apierrors.IsNotFound(err) {
+				l.Error(err, "failed to lookup obsolete resource")
+				errCount++
+			}
+			continue
+		}
+
+		// Only remove what this stack created, never an object of the same name
+		// managed by someone else.
+		if !metav1.IsControlledBy(obj, &stack) {
+			continue
+		}
+
+		if err := k.Delete(ctx, obj); client.IgnoreNotFound(err) != nil {
+			l.Error(err, "failed to delete obsolete resource")
+			errCount++
+			continue
+		}
+		l.Info("Obsolete resource has been deleted")
+	}
+
+	if errCount > 0 {
+		return kverrors.New("failed to configure ssdloki resources", "name", req.NamespacedName)
+	}
+
+	if len(volumeClaims) > 0 {
+		// The rest of the spec is applied, only the volumes keep their settings.
+		return &status.DegradedError{
+			Message: fmt.Sprintf("Volume size and storage class cannot be changed on existing StatefulSets %s, delete them with --cascade=orphan to recreate them", strings.Join(volumeClaims, ", ")),
+			Reason:  ssdlokiv1.ReasonImmutableVolumeClaims,
+			Requeue: false,
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,55 @@
+package lokiclient
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+
+	"github.com/ViaQ/logerr/kverrors"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ssdlokiv1 "github.com/ssd-loki/loki-operator/api/v1"
+	"github.com/ssd-loki/loki-operator/internal/manifests"
+)
+
+// ForStack returns a copy of hc that verifies the certificate of a tier of the
+// stack against serverName with the CA bundle of internal TLS, or hc itself if
+// internal TLS is disabled. Keep-alives are disabled as callers discard the
+// transport after use.
+func ForStack(ctx context.Context, k client.Client, hc *http.Client, stack *ssdlokiv1.SsdLoki, serverName string) (*http.Client, error) {
+	if !manifests.InternalTLSEnabled(stack) {
+		return hc, nil
+	}
+	ca := stack.Spec.TLS.CA
+	if ca == nil {
+		return nil, kverrors.New("internal tls has no ca")
+	}
+
+	var cm corev1.ConfigMap
+	key := client.ObjectKey{Name: ca.CA, Namespace: stack.Namespace}
+	if err := k.Get(ctx, key, &cm); err != nil {
+		return nil, kverrors.Wrap(err, "failed to lookup ca configmap", "name", key)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM([]byte(cm.Data[manifests.CAKey(ca)])) {
+		return nil, kverrors.New("no certificates in ca bundle", "name", key)
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if t, ok := hc.Transport.(*http.Transport); ok {
+		transport = t.Clone()
+	}
+	transport.DisableKeepAlives = true
+	transport.TLSClientConfig = &tls.Config{
+		RootCAs:    pool,
+		ServerName: serverName,
+		MinVersion: tls.VersionTLS12,
+	}
+
+	c := *hc
+	c.Transport = transport
+	return &c, nil
+}
@@ -0,0 +1,243 @@
+package manifests
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+
+	ssdlokiv1 "github.com/ssd-loki/loki-operator/api/v1"
+	"github.com/ssd-loki/loki-operator/internal/manifests/internal/config"
+)
+
+const (
+	alertManagerSecretsMountDir = "/var/run/alertmanager/secrets"
+	alertManagerCAMountDir      = "/var/run/alertmanager/ca"
+
+	// envAlertManagerBasicAuthPassword holds the basic auth password of the
+	// Alertmanager client of the stack, see envPlaceholder.
+	envAlertManagerBasicAuthPassword = "ALERTMANAGER_BASIC_AUTH_PASSWORD"
+)
+
+// AlertManagerClients returns the Alertmanager clients of the stack and of its
+// tenant overrides, with the client of the stack first and the tenants sorted.
+func AlertManagerClients(stack *ssdlokiv1.SsdLoki) []*ssdlokiv1.AlertManagerClientSpec {
+	r := stack.Spec.Ruler
+	if r == nil {
+		return nil
+	}
+
+	var clients []*ssdlokiv1.AlertManagerClientSpec
+	if r.AlertManager != nil && r.AlertManager.Client != nil {
+		clients = append(clients, r.AlertManager.Client)
+	}
+
+	tenants := make([]string, 0, len(r.Overrides))
+	for tenant := range r.Overrides {
+		tenants = append(tenants, tenant)
+	}
+	sort.Strings(tenants)
+
+	for _, tenant := range tenants {
+		am := r.Overrides[tenant].AlertManager
+		if am != nil && am.Client != nil {
+			clients = append(clients, am.Client)
+		}
+	}
+	return clients
+}
+
+// AlertManagerSecretNames returns the names of the Secrets mounted for the
+// Alertmanager clients: client certificates and header credentials.
+func AlertManagerSecretNames(stack *ssdlokiv1.SsdLoki) []string {
+	var names []string
+	for _, c := range AlertManagerClients(stack) {
+		if c.TLS != nil && c.TLS.CertSecretName != "" {
+			names = appendUnique(names, c.TLS.CertSecretName)
+		}
+		if c.HeaderAuth != nil {
+			names = appendUnique(names, c.HeaderAuth.Credentials.Name)
+		}
+	}
+	return names
+}
+
+// AlertManagerConfigMapNames returns the names of the CA ConfigMaps mounted for
+// the Alertmanager clients.
+func AlertManagerConfigMapNames(stack *ssdlokiv1.SsdLoki) []string {
+	var names []string
+	for _, c := range AlertManagerClients(stack) {
+		if c.TLS != nil && c.TLS.CA != nil {
+			names = appendUnique(names, c.TLS.CA.CA)
+		}
+	}
+	return names
+}
+
+func appendUnique(names []string, name string) []string {
+	if slices.Contains(names, name) {
+		return names
+	}
+	return append(names, name)
+}
+
+// alertManagerConfigOptions returns the Alertmanager options of the ruler of the stack.
+func alertManagerConfigOptions(opts Options) *config.AlertManagerConfig {
+	r := opts.Stack.Spec.Ruler
+	if r == nil || r.AlertManager == nil {
+		return nil
+	}
+
+	am := r.AlertManager
+	cfg := alertManagerConfig(&am.AlertManagerOverrideSpec)
+	cfg.ExternalURL = am.ExternalURL
+	cfg.ExternalLabels = am.ExternalLabels
+	cfg.ForOutageTolerance = string(am.ForOutageTolerance)
+	cfg.ForGracePeriod = string(am.ForGracePeriod)
+	cfg.ResendDelay = string(am.ResendDelay)
+
+	if c := am.Client; c != nil && c.BasicAuth != nil {
+		cfg.Notifier.BasicAuth.Password = ptr.To(envPlaceholder(envAlertManagerBasicAuthPassword))
+	}
+	return cfg
+}
+
+// alertManagerConfig converts the Alertmanager settings that can be overridden
+// per tenant. The basic auth password is left to the caller.
+func alertManagerConfig(am *ssdlokiv1.AlertManagerOverrideSpec) *config.AlertManagerConfig {
+	cfg := &config.AlertManagerConfig{
+		Hosts:    strings.Join(am.Endpoints, ","),
+		EnableV2: true,
+	}
+
+	if d := am.Discovery; d != nil {
+		cfg.EnableDiscovery = d.EnableSRV
+		cfg.RefreshInterval = string(d.RefreshInterval)
+	}
+
+	if q := am.NotificationQueue; q != nil {
+		cfg.QueueCapacity = q.Capacity
+		cfg.Timeout = string(q.Timeout)
+	}
+
+	for _, rc := range am.RelabelConfigs {
+		cfg.RelabelConfigs = append(cfg.RelabelConfigs, relabelConfig(rc))
+	}
+
+	if c := am.Client; c != nil {
+		n := &config.NotifierConfig{}
+		if t := c.TLS; t != nil {
+			if t.CA != nil {
+				n.TLS.CAPath = ptr.To(path.Join(alertManagerCAMountDir, t.CA.CA, CAKey(t.CA)))
+			}
+			if t.CertSecretName != "" {
+				n.TLS.CertPath = ptr.To(path.Join(alertManagerSecretsMountDir, t.CertSecretName, corev1.TLSCertKey))
+				n.TLS.KeyPath = ptr.To(path.Join(alertManagerSecretsMountDir, t.CertSecretName, corev1.TLSPrivateKeyKey))
+			}
+			if t.ServerName != "" {
+				n.TLS.ServerName = ptr.To(t.ServerName)
+			}
+			if t.InsecureSkipVerify {
+				n.TLS.InsecureSkipVerify = ptr.To(true)
+			}
+		}
+		if b := c.BasicAuth; b != nil {
+			n.BasicAuth.Username = ptr.To(b.Username)
+		}
+		if h := c.HeaderAuth; h != nil {
+			if h.Type != "" {
+				n.HeaderAuth.Type = ptr.To(h.Type)
+			}
+			n.HeaderAuth.CredentialsFile = ptr.To(path.Join(alertManagerSecretsMountDir, h.Credentials.Name, h.Credentials.Key))
+		}
+		cfg.Notifier = n
+	}
+
+	return cfg
+}
+
+func relabelConfig(rc ssdlokiv1.RelabelConfigSpec) config.RelabelConfig {
+	return config.RelabelConfig{
+		SourceLabels: rc.SourceLabels,
+		Separator:    rc.Separator,
+		TargetLabel:  rc.TargetLabel,
+		Regex:        rc.Regex,
+		Modulus:      rc.Modulus,
+		Replacement:  rc.Replacement,
+		Action:       string(rc.Action),
+	}
+}
+
+// rulerOverridesOptions returns the per-tenant ruler overrides of the runtime config.
+func rulerOverridesOptions(r *ssdlokiv1.RulerConfig) map[string]config.RulerOverrides {
+	if r == nil || len(r.Overrides) == 0 {
+		return nil
+	}
+
+	overrides := make(map[string]config.RulerOverrides, len(r.Overrides))
+	for tenant, o := range r.Overrides {
+		if o.AlertManager == nil {
+			continue
+		}
+		overrides[tenant] = config.RulerOverrides{
+			AlertManager: alertManagerConfig(o.AlertManager),
+		}
+	}
+	return overrides
+}
+
+// configureAlertManager mounts the Secrets and CA ConfigMaps of the Alertmanager
+// clients into the pods of the ruler and exposes the basic auth password of the
+// stack as an environment variable.
+func configureAlertManager(sts *appsv1.StatefulSet, opts Options) {
+	spec := &sts.Spec.Template.Spec
+	c := &spec.Containers[0]
+
+	for i, name := range AlertManagerSecretNames(&opts.Stack) {
+		volume := fmt.Sprintf("alertmanager-secret-%d", i)
+		spec.Volumes = append(spec.Volumes, corev1.Volume{
+			Name: volume,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{SecretName: name},
+			},
+		})
+		c.VolumeMounts = append(c.VolumeMounts, corev1.VolumeMount{
+			Name:      volume,
+			ReadOnly:  true,
+			MountPath: path.Join(alertManagerSecretsMountDir, name),
+		})
+	}
+
+	for i, name := range AlertManagerConfigMapNames(&opts.Stack) {
+		volume := fmt.Sprintf("alertmanager-ca-%d", i)
+		spec.Volumes = append(spec.Volumes, corev1.Volume{
+			Name: volume,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: name},
+				},
+			},
+		})
+		c.VolumeMounts = append(c.VolumeMounts, corev1.VolumeMount{
+			Name:      volume,
+			ReadOnly:  true,
+			MountPath: path.Join(alertManagerCAMountDir, name),
+		})
+	}
+
+	r := opts.Stack.Spec.Ruler
+	if r != nil && r.AlertManager != nil && r.AlertManager.Client != nil && r.AlertManager.Client.BasicAuth != nil {
+		password := r.AlertManager.Client.BasicAuth.Password
+		c.Env = append(c.Env, corev1.EnvVar{
+			Name: envAlertManagerBasicAuthPassword,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &password,
+			},
+		})
+	}
+}
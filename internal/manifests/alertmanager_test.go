@@ -0,0 +1,145 @@
+package manifests
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+
+	ssdlokiv1 "github.com/ssd-loki/loki-operator/api/v1"
+	"github.com/ssd-loki/loki-operator/internal/manifests/internal/config"
+)
+
+func alertManagerSpec(spec *ssdlokiv1.SsdLokiSpec) {
+	spec.Ruler = &ssdlokiv1.RulerConfig{
+		AlertManager: &ssdlokiv1.AlertManagerSpec{
+			AlertManagerOverrideSpec: ssdlokiv1.AlertManagerOverrideSpec{
+				Endpoints: []string{"https://alertmanager-0.monitoring.svc:9093", "https://alertmanager-1.monitoring.svc:9093"},
+				NotificationQueue: &ssdlokiv1.AlertManagerNotificationQueueSpec{
+					Capacity: 5000,
+					Timeout:  "15s",
+				},
+				RelabelConfigs: []ssdlokiv1.RelabelConfigSpec{
+					{SourceLabels: []string{"namespace"}, TargetLabel: "team", Regex: "team-(.*)", Replacement: "$1"},
+				},
+				Client: &ssdlokiv1.AlertManagerClientSpec{
+					TLS: &ssdlokiv1.AlertManagerClientTLSSpec{
+						CA:             &ssdlokiv1.CASpec{CA: "alertmanager-ca"},
+						CertSecretName: "alertmanager-client",
+					},
+					BasicAuth: &ssdlokiv1.AlertManagerClientBasicAuthSpec{
+						Username: "loki",
+						Password: corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "alertmanager-auth"},
+							Key:                  "password",
+						},
+					},
+				},
+			},
+			ExternalURL:    "https://grafana.example.com",
+			ExternalLabels: map[string]string{"cluster": "prod"},
+			ResendDelay:    "2m",
+		},
+		Overrides: map[string]ssdlokiv1.RulerOverridesSpec{
+			"team-a": {
+				AlertManager: &ssdlokiv1.AlertManagerOverrideSpec{
+					Endpoints: []string{"http://alertmanager.team-a.svc:9093"},
+					Discovery: &ssdlokiv1.AlertManagerDiscoverySpec{RefreshInterval: "30s"},
+					Client: &ssdlokiv1.AlertManagerClientSpec{
+						HeaderAuth: &ssdlokiv1.AlertManagerClientHeaderAuthSpec{
+							Credentials: corev1.SecretKeySelector{
+								LocalObjectReference: corev1.LocalObjectReference{Name: "team-a-token"},
+								Key:                  "token",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestLokiConfigMap_AlertManager(t *testing.T) {
+	g := NewWithT(t)
+
+	cm, err := LokiConfigMap(newOptions(t, alertManagerSpec))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	cfg := map[string]interface{}{}
+	g.Expect(yaml.Unmarshal([]byte(cm.Data[config.LokiConfigFileName]), &cfg)).To(Succeed())
+
+	g.Expect(cfg["ruler"]).To(And(
+		HaveKeyWithValue("alertmanager_url", "https://alertmanager-0.monitoring.svc:9093,https://alertmanager-1.monitoring.svc:9093"),
+		HaveKeyWithValue("enable_alertmanager_v2", true),
+		HaveKeyWithValue("external_url", "https://grafana.example.com"),
+		HaveKeyWithValue("external_labels", HaveKeyWithValue("cluster", "prod")),
+		HaveKeyWithValue("notification_queue_capacity", BeNumerically("==", 5000)),
+		HaveKeyWithValue("notification_timeout", "15s"),
+		HaveKeyWithValue("resend_delay", "2m"),
+		HaveKeyWithValue("alert_relabel_configs", ConsistOf(And(
+			HaveKeyWithValue("source_labels", ConsistOf("namespace")),
+			HaveKeyWithValue("separator", ";"),
+			HaveKeyWithValue("target_label", "team"),
+			HaveKeyWithValue("regex", "team-(.*)"),
+			HaveKeyWithValue("replacement", "$1"),
+		))),
+		HaveKeyWithValue("alertmanager_client", And(
+			HaveKeyWithValue("basic_auth_username", "loki"),
+			HaveKeyWithValue("basic_auth_password", "${ALERTMANAGER_BASIC_AUTH_PASSWORD}"),
+			HaveKeyWithValue("tls_ca_path", "/var/run/alertmanager/ca/alertmanager-ca/service-ca.crt"),
+			HaveKeyWithValue("tls_cert_path", "/var/run/alertmanager/secrets/alertmanager-client/tls.crt"),
+			HaveKeyWithValue("tls_key_path", "/var/run/alertmanager/secrets/alertmanager-client/tls.key"),
+		)),
+	))
+	g.Expect(cfg["ruler"]).NotTo(HaveKey("storage"))
+}
+
+func TestLokiRuntimeConfigMap_AlertManagerOverrides(t *testing.T) {
+	g := NewWithT(t)
+
+	cm, err := LokiRuntimeConfigMap(newOptions(t, alertManagerSpec))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	cfg := map[string]interface{}{}
+	g.Expect(yaml.Unmarshal([]byte(cm.Data[config.LokiRuntimeConfigFileName]), &cfg)).To(Succeed())
+
+	overrides := cfg["overrides"].(map[string]interface{})
+	g.Expect(overrides).To(HaveKeyWithValue("team-a", HaveKeyWithValue("ruler_alertmanager_config", And(
+		HaveKeyWithValue("alertmanager_url", "http://alertmanager.team-a.svc:9093"),
+		HaveKeyWithValue("alertmanager_refresh_interval", "30s"),
+		HaveKeyWithValue("alertmanager_client", And(
+			HaveKeyWithValue("credentials_file", "/var/run/alertmanager/secrets/team-a-token/token"),
+			Not(HaveKey("basic_auth_password")),
+		)),
+		Not(HaveKey("external_url")),
+	))))
+}
+
+func TestBuildAll_AlertManagerMountsSecrets(t *testing.T) {
+	g := NewWithT(t)
+
+	sts := statefulSets(t, newOptions(t, alertManagerSpec))
+	backend := sts["loki-backend"].Spec.Template.Spec
+
+	g.Expect(backend.Volumes).To(ContainElements(
+		And(HaveField("Name", "alertmanager-secret-0"), HaveField("VolumeSource.Secret.SecretName", "alertmanager-client")),
+		And(HaveField("Name", "alertmanager-secret-1"), HaveField("VolumeSource.Secret.SecretName", "team-a-token")),
+		And(HaveField("Name", "alertmanager-ca-0"), HaveField("VolumeSource.ConfigMap.Name", "alertmanager-ca")),
+	))
+	g.Expect(backend.Volumes).NotTo(ContainElement(HaveField("Name", "alertmanager-secret-2")))
+
+	c := backend.Containers[0]
+	g.Expect(c.VolumeMounts).To(ContainElements(
+		HaveField("MountPath", "/var/run/alertmanager/secrets/alertmanager-client"),
+		HaveField("MountPath", "/var/run/alertmanager/secrets/team-a-token"),
+		HaveField("MountPath", "/var/run/alertmanager/ca/alertmanager-ca"),
+	))
+	g.Expect(c.Env).To(ContainElement(And(
+		HaveField("Name", "ALERTMANAGER_BASIC_AUTH_PASSWORD"),
+		HaveField("ValueFrom.SecretKeyRef.Name", "alertmanager-auth"),
+		HaveField("ValueFrom.SecretKeyRef.Key", "password"),
+	)))
+
+	g.Expect(sts["loki-read"].Spec.Template.Spec.Volumes).NotTo(ContainElement(HaveField("Name", "alertmanager-secret-0")))
+}
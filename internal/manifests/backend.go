@@ -9,10 +9,21 @@ import (
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/ssd-loki/loki-operator/internal/manifests/storage"
 )
 
 func BuildBackend(opts Options) ([]client.Object, error) {
 	statefulset := NewBackendStatefulSet(opts)
+	if err := storage.ConfigureStatefulSet(statefulset, opts.ObjectStorage); err != nil {
+		return nil, err
+	}
+	configureInternalTLS(statefulset, opts, ComponentBackend)
+	configureRules(statefulset, opts)
+	configureAlertManager(statefulset, opts)
+	configureRemoteWrite(statefulset, opts)
+	configurePodTemplate(statefulset, opts.Stack.Spec.Template.Backend)
+
 	objs := []client.Object{
 		statefulset,
 		NewLokiBackendService(opts),
@@ -24,29 +35,39 @@ func BuildBackend(opts Options) ([]client.Object, error) {
 }
 
 func NewBackendStatefulSet(opts Options) *appsv1.StatefulSet {
-	backendLabels := commonLabels("backend", opts.Name)
+	backendLabels := commonLabels(opts.Name, ComponentBackend)
 
 	// 컨테이너 정의
 	container := corev1.Container{
 		Name:            "loki",
-		Image:           defaultImage,
+		Image:           opts.Image,
 		ImagePullPolicy: corev1.PullIfNotPresent,
 		Args: []string{
 			"-config.file=/etc/loki/config/config.yaml", //TODO
+			"-config.expand-env=true",
 			"-target=backend",
 			"-legacy-read-mode=false",
 		},
 		Ports: []corev1.ContainerPort{
 			{
 				Name:          lokiHTTPPortName,
-				ContainerPort: httpPort,
+				ContainerPort: opts.lokiHTTPPort(),
 				Protocol:      protocolTCP,
 			},
 			{
 				Name:          lokiGRPCPortName,
-				ContainerPort: grpcPort,
+				ContainerPort: opts.lokiGRPCPort(),
 				Protocol:      protocolTCP,
 			},
+			{
+				Name:          lokiMemberListPortName,
+				ContainerPort: memberListPort,
+				Protocol:      protocolTCP,
+			},
+		},
+		Resources: corev1.ResourceRequirements{
+			Limits:   opts.ResourceRequirements.Backend.Limits,
+			Requests: opts.ResourceRequirements.Backend.Requests,
 		},
 		SecurityContext: &corev1.SecurityContext{
 			AllowPrivilegeEscalation: ptr.To(false),
@@ -59,7 +80,7 @@ func NewBackendStatefulSet(opts Options) *appsv1.StatefulSet {
 			ProbeHandler: corev1.ProbeHandler{
 				HTTPGet: &corev1.HTTPGetAction{
 					Path: "/ready",
-					Port: intstr.IntOrString{IntVal: httpPort},
+					Port: intstr.FromInt32(opts.lokiHTTPPort()),
 				},
 			},
 			InitialDelaySeconds: 30,
@@ -83,7 +104,7 @@ func NewBackendStatefulSet(opts Options) *appsv1.StatefulSet {
 
 	// PodSpec 정의
 	podSpec := corev1.PodSpec{
-		ServiceAccountName:            "loki",
+		ServiceAccountName:            serviceAccountName(opts.Name),
 		AutomountServiceAccountToken:  ptr.To(true),
 		EnableServiceLinks:            ptr.To(true),
 		TerminationGracePeriodSeconds: ptr.To(int64(300)),
@@ -93,15 +114,14 @@ func NewBackendStatefulSet(opts Options) *appsv1.StatefulSet {
 			RunAsNonRoot: ptr.To(true),
 			RunAsUser:    ptr.To(int64(10001)),
 		},
-		Containers: []corev1.Container{container},
+		Containers:                []corev1.Container{container},
+		TopologySpreadConstraints: topologySpreadConstraints(opts, ComponentBackend),
 		Affinity: &corev1.Affinity{
 			PodAntiAffinity: &corev1.PodAntiAffinity{
 				RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{
 					{
 						LabelSelector: &metav1.LabelSelector{
-							MatchLabels: map[string]string{
-								"app.kubernetes.io/component": "backend",
-							},
+							MatchLabels: backendLabels,
 						},
 						TopologyKey: "kubernetes.io/hostname",
 					},
@@ -114,7 +134,7 @@ func NewBackendStatefulSet(opts Options) *appsv1.StatefulSet {
 				VolumeSource: corev1.VolumeSource{
 					ConfigMap: &corev1.ConfigMapVolumeSource{
 						LocalObjectReference: corev1.LocalObjectReference{
-							Name: "loki",
+							Name: configMapName(opts.Name),
 						},
 						Items: []corev1.KeyToPath{
 							{
@@ -130,7 +150,7 @@ func NewBackendStatefulSet(opts Options) *appsv1.StatefulSet {
 				VolumeSource: corev1.VolumeSource{
 					ConfigMap: &corev1.ConfigMapVolumeSource{
 						LocalObjectReference: corev1.LocalObjectReference{
-							Name: "loki-runtime",
+							Name: runtimeConfigMapName(opts.Name),
 						},
 					},
 				},
@@ -151,19 +171,20 @@ func NewBackendStatefulSet(opts Options) *appsv1.StatefulSet {
 			APIVersion: appsv1.SchemeGroupVersion.String(),
 		},
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      "loki-backend",
-			Namespace: "default",
-			Labels:    labels.Merge(memberListLabels(), backendLabels),
+			Name:      BackendName(opts.Name),
+			Namespace: opts.Namespace,
+			Labels:    labels.Merge(memberListLabels(opts.Name), backendLabels),
 		},
 		Spec: appsv1.StatefulSetSpec{
-			Replicas: ptr.To(int32(3)),
+			Replicas: ptr.To(*opts.Stack.Spec.Template.Backend.Replicas),
 			Selector: &metav1.LabelSelector{
 				MatchLabels: backendLabels,
 			},
-			ServiceName: "loki-backend-headless",
+			ServiceName: headlessServiceName(BackendName(opts.Name)),
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels: labels.Merge(memberListLabels(), backendLabels),
+					Labels:      labels.Merge(memberListLabels(opts.Name), backendLabels),
+					Annotations: podAnnotations(opts),
 				},
 				Spec: podSpec,
 			},
@@ -179,6 +200,7 @@ func NewBackendStatefulSet(opts Options) *appsv1.StatefulSet {
 						AccessModes: []corev1.PersistentVolumeAccessMode{
 							corev1.ReadWriteOnce,
 						},
+						StorageClassName: storageClassName(opts.Stack.Spec.Template.Backend),
 						Resources: corev1.VolumeResourceRequirements{
 							Requests: corev1.ResourceList{
 								corev1.ResourceStorage: opts.ResourceRequirements.Backend.PVCSize,
@@ -197,8 +219,8 @@ func NewBackendStatefulSet(opts Options) *appsv1.StatefulSet {
 }
 
 func NewLokiBackendService(opts Options) *corev1.Service {
-	serviceName := "loki-backend"
-	backendLabels := commonLabels(opts.Name, "backend")
+	serviceName := BackendName(opts.Name)
+	backendLabels := commonLabels(opts.Name, ComponentBackend)
 
 	// Return the new service object
 	return &corev1.Service{
@@ -208,7 +230,7 @@ func NewLokiBackendService(opts Options) *corev1.Service {
 		},
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      serviceName,
-			Namespace: "default",
+			Namespace: opts.Namespace,
 			Labels:    backendLabels,
 		},
 		Spec: corev1.ServiceSpec{
@@ -216,15 +238,15 @@ func NewLokiBackendService(opts Options) *corev1.Service {
 			Ports: []corev1.ServicePort{
 				{
 					Name:       lokiHTTPPortName,
-					Port:       httpPort,
+					Port:       opts.lokiHTTPPort(),
 					Protocol:   protocolTCP,
-					TargetPort: intstr.IntOrString{StrVal: lokiHTTPPortName},
+					TargetPort: intstr.FromString(lokiHTTPPortName),
 				},
 				{
 					Name:       lokiGRPCPortName,
-					Port:       grpcPort,
+					Port:       opts.lokiGRPCPort(),
 					Protocol:   protocolTCP,
-					TargetPort: intstr.IntOrString{StrVal: lokiGRPCPortName},
+					TargetPort: intstr.FromString(lokiGRPCPortName),
 				},
 			},
 			Selector: backendLabels,
@@ -234,12 +256,8 @@ func NewLokiBackendService(opts Options) *corev1.Service {
 
 // NewLokiBackendHeadlessService returns a new headless service for the Loki backend.
 func NewLokiBackendHeadlessService(opts Options) *corev1.Service {
-	serviceName := "loki-backend-headless"
-	backendLabels := commonLabels(opts.Name, "backend")
-	headlessServiceLabels := map[string]string{
-		"variant":                       "headless",
-		"prometheus.io/service-monitor": "false",
-	}
+	serviceName := headlessServiceName(BackendName(opts.Name))
+	backendLabels := commonLabels(opts.Name, ComponentBackend)
 	// Return the new service object
 	return &corev1.Service{
 		TypeMeta: metav1.TypeMeta{
@@ -248,8 +266,8 @@ func NewLokiBackendHeadlessService(opts Options) *corev1.Service {
 		},
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      serviceName,
-			Namespace: "default",
-			Labels:    labels.Merge(backendLabels, headlessServiceLabels),
+			Namespace: opts.Namespace,
+			Labels:    labels.Merge(backendLabels, headlessServiceLabels()),
 		},
 		Spec: corev1.ServiceSpec{
 			Type:      corev1.ServiceTypeClusterIP,
@@ -257,15 +275,15 @@ func NewLokiBackendHeadlessService(opts Options) *corev1.Service {
 			Ports: []corev1.ServicePort{
 				{
 					Name:       lokiHTTPPortName,
-					Port:       httpPort,
+					Port:       opts.lokiHTTPPort(),
 					Protocol:   protocolTCP,
-					TargetPort: intstr.IntOrString{StrVal: lokiHTTPPortName},
+					TargetPort: intstr.FromString(lokiHTTPPortName),
 				},
 				{
 					Name:       lokiGRPCPortName,
-					Port:       grpcPort,
+					Port:       opts.lokiGRPCPort(),
 					Protocol:   protocolTCP,
-					TargetPort: intstr.IntOrString{StrVal: lokiGRPCPortName},
+					TargetPort: intstr.FromString(lokiGRPCPortName),
 				},
 			},
 			Selector: backendLabels,
@@ -273,10 +291,10 @@ func NewLokiBackendHeadlessService(opts Options) *corev1.Service {
 	}
 }
 
-// NewQuerierPodDisruptionBudget returns a PodDisruptionBudget for the LokiStack querier pods.
+// NewBackendPodDisruptionBudget returns a PodDisruptionBudget for the Loki backend pods.
 func NewBackendPodDisruptionBudget(opts Options) *policyv1.PodDisruptionBudget {
-	name := "loki-backend"
-	labels := commonLabels(opts.Name, "backend")
+	name := BackendName(opts.Name)
+	labels := commonLabels(opts.Name, ComponentBackend)
 
 	return &policyv1.PodDisruptionBudget{
 		TypeMeta: metav1.TypeMeta{
@@ -285,7 +303,7 @@ func NewBackendPodDisruptionBudget(opts Options) *policyv1.PodDisruptionBudget {
 		},
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      name,
-			Namespace: "default",
+			Namespace: opts.Namespace,
 			Labels:    labels,
 		},
 		Spec: policyv1.PodDisruptionBudgetSpec{
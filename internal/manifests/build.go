@@ -0,0 +1,134 @@
+package manifests
+
+import (
+	"crypto/sha1"
+	"fmt"
+
+	"github.com/ViaQ/logerr/kverrors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/ssd-loki/loki-operator/internal/manifests/internal/config"
+)
+
+// BuildAll builds all manifests required to run a Loki simple scalable deployment.
+func BuildAll(opts Options) ([]client.Object, error) {
+	res := make([]client.Object, 0)
+
+	rulesNames, err := rulesConfigMapNames(opts)
+	if err != nil {
+		return nil, err
+	}
+	opts.RulesConfigMapNames = rulesNames
+
+	cm, err := LokiConfigMap(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	rcm, err := LokiRuntimeConfigMap(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// The runtime config is reloaded by Loki and therefore not part of the hash.
+	opts.ConfigSHA1 = configHash(cm.Data[config.LokiConfigFileName], opts.ObjectStorage.SecretSHA1, opts.CertificatesSHA1, opts.AlertManagerSHA1, rulerSecretHash(opts.RulerSecret))
+
+	res = append(res, cm, rcm)
+	res = append(res, buildLokiSA(opts))
+	res = append(res, NewLokiMemberListService(opts))
+
+	readObjs, err := BuildRead(opts)
+	if err != nil {
+		return nil, err
+	}
+	res = append(res, readObjs...)
+
+	writeObjs, err := BuildWrite(opts)
+	if err != nil {
+		return nil, err
+	}
+	res = append(res, writeObjs...)
+
+	backendObjs, err := BuildBackend(opts)
+	if err != nil {
+		return nil, err
+	}
+	res = append(res, backendObjs...)
+	res = append(res, BuildMemcached(opts)...)
+
+	rulesObjs, err := BuildRules(opts)
+	if err != nil {
+		return nil, err
+	}
+	res = append(res, rulesObjs...)
+
+	gatewayObjs, err := BuildGateway(opts)
+	if err != nil {
+		return nil, err
+	}
+	res = append(res, gatewayObjs...)
+
+	return res, nil
+}
+
+// ObsoleteObjects returns the objects of optional components that are disabled
+// in the spec and must be removed if they were created before.
+func ObsoleteObjects(opts Options) []client.Object {
+	var objs []client.Object
+	objs = append(objs, obsoleteMemcachedObjects(opts)...)
+	objs = append(objs, obsoleteGatewayObjects(opts)...)
+	return objs
+}
+
+// ApplyDefaultSettings manipulates the options to conform to
+// build specifications
+func ApplyDefaultSettings(opts *Options) error {
+	if opts.Image == "" {
+		opts.Image = defaultImage
+	}
+	if opts.GatewayImage == "" {
+		opts.GatewayImage = defaultGatewayImage
+	}
+	if opts.MemcachedImage == "" {
+		opts.MemcachedImage = defaultMemcachedImage
+	}
+	if opts.MemcachedExporterImage == "" {
+		opts.MemcachedExporterImage = defaultMemcachedExporterImage
+	}
+
+	if opts.TLSProfile.MinTLSVersion == "" {
+		opts.TLSProfile = tlsProfile(opts.Stack)
+	}
+
+	if err := applySpecDefaults(opts); err != nil {
+		return err
+	}
+
+	spec := opts.Stack.Spec
+	timeouts, err := NewTimeoutConfig(spec.LimitsConfig, spec.Overrides)
+	if err != nil {
+		return kverrors.Wrap(err, "failed to parse query timeout")
+	}
+	opts.Timeouts = timeouts
+
+	tpl := opts.Stack.Spec.Template
+	opts.ResourceRequirements = ComponentResources{
+		Read:    resourceRequirementsFor(tpl.Read),
+		Write:   resourceRequirementsFor(tpl.Write),
+		Backend: resourceRequirementsFor(tpl.Backend),
+	}
+
+	return nil
+}
+
+// configHash returns a hash of the rendered Loki config and the hashes of the
+// Secrets it references, so that pods roll whenever any of them changes.
+func configHash(cfg string, secretHashes ...string) string {
+	h := sha1.New()
+	_, _ = h.Write([]byte(cfg))
+	for _, sh := range secretHashes {
+		_, _ = h.Write([]byte{0})
+		_, _ = h.Write([]byte(sh))
+	}
+	return fmt.Sprintf("%x", h.Sum(nil))
+}
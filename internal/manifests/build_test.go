@@ -0,0 +1,317 @@
+package manifests
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/utils/ptr"
+
+	ssdlokiv1 "github.com/ssd-loki/loki-operator/api/v1"
+)
+
+// newOptions returns the defaulted options of a stack named loki in namespace
+// ns, whose spec is set up by mutate if not nil.
+func newOptions(t *testing.T, mutate func(*ssdlokiv1.SsdLokiSpec)) Options {
+	t.Helper()
+
+	opts := Options{Name: "loki", Namespace: "ns"}
+	if mutate != nil {
+		mutate(&opts.Stack.Spec)
+	}
+	NewWithT(t).Expect(ApplyDefaultSettings(&opts)).To(Succeed())
+	return opts
+}
+
+func TestBuildAll_ContainsAllTiers(t *testing.T) {
+	g := NewWithT(t)
+
+	objs, err := BuildAll(newOptions(t, nil))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	var names []string
+	for _, obj := range objs {
+		if sts, ok := obj.(*appsv1.StatefulSet); ok {
+			names = append(names, sts.Name)
+		}
+	}
+	g.Expect(names).To(ConsistOf("loki-read", "loki-write", "loki-backend"))
+}
+
+func TestBuildAll_ServicesSelectStatefulSetPods(t *testing.T) {
+	g := NewWithT(t)
+
+	objs, err := BuildAll(newOptions(t, nil))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	var pods []labels.Set
+	for _, obj := range objs {
+		if sts, ok := obj.(*appsv1.StatefulSet); ok {
+			pods = append(pods, sts.Spec.Template.Labels)
+		}
+	}
+
+	for _, obj := range objs {
+		svc, ok := obj.(*corev1.Service)
+		if !ok {
+			continue
+		}
+
+		selector := labels.SelectorFromSet(svc.Spec.Selector)
+		matched := false
+		for _, l := range pods {
+			if selector.Matches(l) {
+				matched = true
+			}
+		}
+		g.Expect(matched).To(BeTrue(), "service %s selects no pods", svc.Name)
+	}
+}
+
+func TestBuildAll_ServiceNamesAreUnique(t *testing.T) {
+	g := NewWithT(t)
+
+	objs, err := BuildAll(newOptions(t, nil))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	seen := map[string]bool{}
+	for _, obj := range objs {
+		if _, ok := obj.(*corev1.Service); !ok {
+			continue
+		}
+		g.Expect(seen).NotTo(HaveKey(obj.GetName()))
+		seen[obj.GetName()] = true
+	}
+}
+
+func TestBuildAll_NamesAndNamespacesFollowStack(t *testing.T) {
+	g := NewWithT(t)
+
+	opts := Options{
+		Name:      "prod",
+		Namespace: "logging",
+	}
+	g.Expect(ApplyDefaultSettings(&opts)).To(Succeed())
+
+	objs, err := BuildAll(opts)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	var names []string
+	for _, obj := range objs {
+		g.Expect(obj.GetNamespace()).To(Equal("logging"), "%s has the wrong namespace", obj.GetName())
+		g.Expect(obj.GetName()).To(HavePrefix("prod"))
+		names = append(names, obj.GetName())
+
+		sts, ok := obj.(*appsv1.StatefulSet)
+		if !ok {
+			continue
+		}
+		g.Expect(sts.Spec.Template.Spec.ServiceAccountName).To(Equal("prod"))
+		for _, v := range sts.Spec.Template.Spec.Volumes {
+			if v.ConfigMap != nil {
+				g.Expect(v.ConfigMap.Name).To(BeElementOf("prod", "prod-runtime"))
+			}
+		}
+	}
+	g.Expect(names).To(ContainElements("prod-read-headless", "prod-write-headless", "prod-backend-headless", "prod-memberlist"))
+
+	cm, err := LokiConfigMap(opts)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(cm.Data["config.yaml"]).To(ContainSubstring("prod-backend-headless.logging.svc.cluster.local"))
+	g.Expect(cm.Data["config.yaml"]).NotTo(ContainSubstring(".default.svc"))
+}
+
+func TestBuildAll_TemplateSizesEachTier(t *testing.T) {
+	g := NewWithT(t)
+
+	opts := Options{
+		Name:      "loki",
+		Namespace: "default",
+		Stack: ssdlokiv1.SsdLoki{
+			Spec: ssdlokiv1.SsdLokiSpec{
+				Template: &ssdlokiv1.SsdLokiTemplateSpec{
+					Read: &ssdlokiv1.SsdLokiComponentSpec{
+						Replicas: ptr.To(int32(5)),
+						Resources: &corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{
+								corev1.ResourceCPU: resource.MustParse("2"),
+							},
+						},
+					},
+					Write: &ssdlokiv1.SsdLokiComponentSpec{
+						StorageClassName: "fast",
+						VolumeSize:       ptr.To(resource.MustParse("50Gi")),
+					},
+				},
+			},
+		},
+	}
+	g.Expect(ApplyDefaultSettings(&opts)).To(Succeed())
+
+	objs, err := BuildAll(opts)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	sts := map[string]*appsv1.StatefulSet{}
+	for _, obj := range objs {
+		if s, ok := obj.(*appsv1.StatefulSet); ok {
+			sts[s.Name] = s
+		}
+	}
+
+	read := sts["loki-read"]
+	g.Expect(*read.Spec.Replicas).To(Equal(int32(5)))
+	g.Expect(read.Spec.Template.Spec.Containers[0].Resources.Requests.Cpu().String()).To(Equal("2"))
+	g.Expect(read.Spec.VolumeClaimTemplates[0].Spec.StorageClassName).To(BeNil())
+
+	write := sts["loki-write"]
+	g.Expect(*write.Spec.Replicas).To(Equal(int32(3)))
+	g.Expect(write.Spec.VolumeClaimTemplates[0].Spec.StorageClassName).To(Equal(ptr.To("fast")))
+	g.Expect(write.Spec.VolumeClaimTemplates[0].Spec.Resources.Requests.Storage().String()).To(Equal("50Gi"))
+
+	backend := sts["loki-backend"]
+	g.Expect(*backend.Spec.Replicas).To(Equal(int32(3)))
+	g.Expect(backend.Spec.VolumeClaimTemplates[0].Spec.Resources.Requests.Storage().String()).To(Equal("10Gi"))
+}
+
+func TestApplyDefaultSettings_SizePreset(t *testing.T) {
+	g := NewWithT(t)
+
+	opts := Options{
+		Name:      "loki",
+		Namespace: "default",
+		Stack: ssdlokiv1.SsdLoki{
+			Spec: ssdlokiv1.SsdLokiSpec{
+				Size: ssdlokiv1.SizeSmall,
+				Template: &ssdlokiv1.SsdLokiTemplateSpec{
+					Write: &ssdlokiv1.SsdLokiComponentSpec{
+						Replicas:   ptr.To(int32(5)),
+						VolumeSize: ptr.To(resource.MustParse("30Gi")),
+					},
+				},
+			},
+		},
+	}
+	g.Expect(ApplyDefaultSettings(&opts)).To(Succeed())
+
+	tpl := opts.Stack.Spec.Template
+	g.Expect(*tpl.Read.Replicas).To(Equal(int32(2)))
+	g.Expect(*tpl.Write.Replicas).To(Equal(int32(5)))
+	g.Expect(*tpl.Backend.Replicas).To(Equal(int32(3)))
+
+	g.Expect(opts.ResourceRequirements.Read.Requests.Cpu().String()).To(Equal("1"))
+	g.Expect(opts.ResourceRequirements.Write.Requests.Memory().String()).To(Equal("4Gi"))
+	g.Expect(opts.ResourceRequirements.Write.PVCSize.String()).To(Equal("30Gi"))
+	g.Expect(opts.ResourceRequirements.Backend.PVCSize.String()).To(Equal("10Gi"))
+}
+
+func TestApplyDefaultSettings_UnknownSize(t *testing.T) {
+	g := NewWithT(t)
+
+	opts := Options{
+		Name:      "loki",
+		Namespace: "default",
+		Stack: ssdlokiv1.SsdLoki{
+			Spec: ssdlokiv1.SsdLokiSpec{Size: "huge"},
+		},
+	}
+	g.Expect(ApplyDefaultSettings(&opts)).NotTo(Succeed())
+}
+
+func configHashes(t *testing.T, opts Options) map[string]string {
+	objs, err := BuildAll(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hashes := map[string]string{}
+	for _, obj := range objs {
+		if sts, ok := obj.(*appsv1.StatefulSet); ok {
+			hashes[sts.Name] = sts.Spec.Template.Annotations[AnnotationLokiConfigHash]
+		}
+	}
+	return hashes
+}
+
+func TestBuildAll_ConfigHashOnEveryTier(t *testing.T) {
+	g := NewWithT(t)
+
+	hashes := configHashes(t, newOptions(t, nil))
+	g.Expect(hashes).To(HaveLen(3))
+	g.Expect(hashes["loki-read"]).NotTo(BeEmpty())
+	g.Expect(hashes["loki-write"]).To(Equal(hashes["loki-read"]))
+	g.Expect(hashes["loki-backend"]).To(Equal(hashes["loki-read"]))
+}
+
+func TestBuildAll_ConfigHashChanges(t *testing.T) {
+	base := configHashes(t, newOptions(t, nil))["loki-read"]
+
+	tt := []struct {
+		desc       string
+		mutate     func(*Options)
+		wantChange bool
+	}{
+		{
+			desc: "rendered config changes",
+			mutate: func(opts *Options) {
+				opts.Stack.Spec.Ingester.ChunkEncoding = "zstd"
+			},
+			wantChange: true,
+		},
+		{
+			desc: "storage secret changes",
+			mutate: func(opts *Options) {
+				opts.ObjectStorage.SecretSHA1 = "deadbeef"
+			},
+			wantChange: true,
+		},
+		{
+			desc: "certificates change",
+			mutate: func(opts *Options) {
+				opts.CertificatesSHA1 = "deadbeef"
+			},
+			wantChange: true,
+		},
+		{
+			desc: "alertmanager secrets change",
+			mutate: func(opts *Options) {
+				opts.AlertManagerSHA1 = "deadbeef"
+			},
+			wantChange: true,
+		},
+		{
+			desc: "ruler secret changes",
+			mutate: func(opts *Options) {
+				opts.RulerSecret = &RulerSecret{BearerToken: "rotated"}
+			},
+			wantChange: true,
+		},
+		{
+			desc: "runtime overrides change",
+			mutate: func(opts *Options) {
+				opts.Stack.Spec.Overrides = map[string]ssdlokiv1.PerTenantLimitsConfig{
+					"tenant-a": {QueryTimeout: "1m"},
+				}
+			},
+			wantChange: false,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.desc, func(t *testing.T) {
+			g := NewWithT(t)
+
+			opts := newOptions(t, nil)
+			tc.mutate(&opts)
+
+			got := configHashes(t, opts)["loki-read"]
+			if tc.wantChange {
+				g.Expect(got).NotTo(Equal(base))
+			} else {
+				g.Expect(got).To(Equal(base))
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package manifests
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	ssdlokiv1 "github.com/ssd-loki/loki-operator/api/v1"
+	"github.com/ssd-loki/loki-operator/internal/manifests/internal/config"
+)
+
+// LokiConfigMap creates the configmap holding the Loki configuration shared by all components.
+func LokiConfigMap(opts Options) (*corev1.ConfigMap, error) {
+	cfg, _, err := config.Build(ConfigOptions(opts))
+	if err != nil {
+		return nil, err
+	}
+
+	return lokiConfigmap(configMapName(opts.Name), opts.Namespace, string(cfg)), nil
+}
+
+// LokiRuntimeConfigMap creates the configmap holding the per-tenant overrides
+// that Loki reloads periodically without restarting pods.
+func LokiRuntimeConfigMap(opts Options) (*corev1.ConfigMap, error) {
+	_, rcfg, err := config.Build(ConfigOptions(opts))
+	if err != nil {
+		return nil, err
+	}
+
+	return lokiRuntimeConfigmap(runtimeConfigMapName(opts.Name), opts.Namespace, string(rcfg)), nil
+}
+
+// ConfigOptions converts Options to config.Options
+func ConfigOptions(opts Options) config.Options {
+	return config.Options{
+		Stack:         opts.Stack,
+		Namespace:     opts.Namespace,
+		Name:          opts.Name,
+		Overrides:     overridesOptions(opts.Stack.Spec),
+		ObjectStorage: opts.ObjectStorage,
+		MaxConcurrent: config.MaxConcurrent{
+			AvailableQuerierCPUCores: querierCPUCores(opts.ResourceRequirements.Read),
+		},
+		WriteAheadLog: config.WriteAheadLog{
+			IngesterMemoryRequest: opts.ResourceRequirements.Write.Requests.Memory().Value(),
+		},
+		ZoneAwarenessEnabled: zoneAwarenessEnabled(opts.Stack),
+		HTTPTimeouts:         opts.Timeouts.Loki,
+		TLS:                  tlsConfigOptions(opts),
+		Rules:                rulesConfigOptions(opts),
+		AlertManager:         alertManagerConfigOptions(opts),
+		RemoteWrite:          remoteWriteConfigOptions(opts),
+		Retention:            retentionConfigOptions(opts),
+	}
+}
+
+// querierCPUCores returns the CPU cores requested by the read tier rounded up,
+// or the Loki default query concurrency when the tier requests no CPU.
+func querierCPUCores(rr ResourceRequirements) int32 {
+	cores := rr.Requests.Cpu().Value()
+	if cores == 0 {
+		return defaultQuerierMaxConcurrent
+	}
+	return int32(cores)
+}
+
+func overridesOptions(spec ssdlokiv1.SsdLokiSpec) map[string]config.LokiOverrides {
+	ruler := rulerOverridesOptions(spec.Ruler)
+	if len(spec.Overrides) == 0 && len(ruler) == 0 {
+		return nil
+	}
+
+	overrides := make(map[string]config.LokiOverrides, len(spec.Overrides))
+	for tenant, limits := range spec.Overrides {
+		overrides[tenant] = config.LokiOverrides{
+			Limits: limits,
+		}
+	}
+	for tenant, r := range ruler {
+		o := overrides[tenant]
+		o.Ruler = r
+		overrides[tenant] = o
+	}
+
+	return overrides
+}
@@ -0,0 +1,365 @@
+package manifests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/yaml"
+
+	ssdlokiv1 "github.com/ssd-loki/loki-operator/api/v1"
+	"github.com/ssd-loki/loki-operator/internal/manifests/internal/config"
+	"github.com/ssd-loki/loki-operator/internal/manifests/storage"
+)
+
+func renderConfig(t *testing.T, spec ssdlokiv1.SsdLokiSpec) map[string]interface{} {
+	t.Helper()
+	g := NewWithT(t)
+
+	opts := Options{
+		Name:      "loki",
+		Namespace: "default",
+		Stack:     ssdlokiv1.SsdLoki{Spec: spec},
+	}
+	g.Expect(ApplyDefaultSettings(&opts)).To(Succeed())
+
+	cm, err := LokiConfigMap(opts)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	out := map[string]interface{}{}
+	g.Expect(yaml.Unmarshal([]byte(cm.Data[config.LokiConfigFileName]), &out)).To(Succeed())
+	return out
+}
+
+func TestLokiConfigMap_DefaultsForEmptySpec(t *testing.T) {
+	g := NewWithT(t)
+
+	cfg := renderConfig(t, ssdlokiv1.SsdLokiSpec{})
+
+	g.Expect(cfg).To(HaveKeyWithValue("auth_enabled", false))
+	g.Expect(cfg["common"]).To(HaveKeyWithValue("replication_factor", float64(3)))
+	g.Expect(cfg["common"]).To(HaveKeyWithValue("storage", HaveKey("filesystem")))
+	g.Expect(cfg["limits_config"]).To(HaveKeyWithValue("query_timeout", "300s"))
+	g.Expect(cfg["limits_config"]).To(HaveKeyWithValue("reject_old_samples", true))
+	g.Expect(cfg["server"]).To(HaveKeyWithValue("http_listen_port", float64(3100)))
+	g.Expect(cfg["memberlist"]).To(HaveKeyWithValue("join_members", ConsistOf("loki-memberlist")))
+	g.Expect(cfg["querier"]).To(HaveKeyWithValue("max_concurrent", float64(4)))
+	g.Expect(cfg["ingester"]).NotTo(HaveKey("wal"))
+
+	schemas := cfg["schema_config"].(map[string]interface{})["configs"].([]interface{})
+	g.Expect(schemas).To(HaveLen(1))
+	g.Expect(schemas[0]).To(HaveKeyWithValue("object_store", "filesystem"))
+	g.Expect(schemas[0]).To(HaveKeyWithValue("from", "2024-04-01"))
+
+	chunkCache := cfg["chunk_store_config"].(map[string]interface{})["chunk_cache_config"]
+	g.Expect(chunkCache).NotTo(HaveKey("memcached_client"))
+}
+
+func TestLokiConfigMap_RendersSpecValues(t *testing.T) {
+	g := NewWithT(t)
+
+	cfg := renderConfig(t, ssdlokiv1.SsdLokiSpec{
+		AuthEnabled: true,
+		Common: &ssdlokiv1.CommonConfig{
+			ReplicationFactor: 2,
+			Storage: &ssdlokiv1.CommonStorage{
+				S3: &ssdlokiv1.S3Config{
+					BucketNames: "logs",
+					Endpoint:    "minio.storage.svc:9000",
+				},
+			},
+		},
+		LimitsConfig: &ssdlokiv1.LimitsConfig{
+			QueryTimeout:     "10m",
+			RejectOldSamples: ptr.To(false),
+		},
+		Ingester: &ssdlokiv1.IngesterConfig{
+			ChunkEncoding: "zstd",
+		},
+		Querier: &ssdlokiv1.QuerierConfig{
+			MaxConcurrent: 16,
+		},
+	})
+
+	g.Expect(cfg).To(HaveKeyWithValue("auth_enabled", true))
+	g.Expect(cfg["common"]).To(HaveKeyWithValue("replication_factor", float64(2)))
+	g.Expect(cfg["common"]).To(HaveKeyWithValue("path_prefix", "/var/loki"))
+	g.Expect(cfg["common"]).To(HaveKeyWithValue("storage", HaveKeyWithValue("s3", HaveKeyWithValue("bucketnames", "logs"))))
+	g.Expect(cfg["limits_config"]).To(HaveKeyWithValue("query_timeout", "10m"))
+	g.Expect(cfg["limits_config"]).To(HaveKeyWithValue("reject_old_samples", false))
+	g.Expect(cfg["limits_config"]).To(HaveKeyWithValue("split_queries_by_interval", "15m"))
+	g.Expect(cfg["ingester"]).To(HaveKeyWithValue("chunk_encoding", "zstd"))
+	g.Expect(cfg["querier"]).To(HaveKeyWithValue("max_concurrent", float64(16)))
+
+	schemas := cfg["schema_config"].(map[string]interface{})["configs"].([]interface{})
+	g.Expect(schemas[0]).To(HaveKeyWithValue("object_store", "s3"))
+}
+
+func TestLokiConfigMap_ServerTimeoutsFollowQueryTimeout(t *testing.T) {
+	g := NewWithT(t)
+
+	cfg := renderConfig(t, ssdlokiv1.SsdLokiSpec{
+		LimitsConfig: &ssdlokiv1.LimitsConfig{QueryTimeout: "10m"},
+		Overrides: map[string]ssdlokiv1.PerTenantLimitsConfig{
+			"team-a": {QueryTimeout: "20m"},
+		},
+	})
+
+	g.Expect(cfg["server"]).To(HaveKeyWithValue("http_server_idle_timeout", "30s"))
+	g.Expect(cfg["server"]).To(HaveKeyWithValue("http_server_read_timeout", "2m0s"))
+	g.Expect(cfg["server"]).To(HaveKeyWithValue("http_server_write_timeout", "21m0s"))
+}
+
+func TestLokiConfigMap_RendersMemcachedWhenAddressesSet(t *testing.T) {
+	g := NewWithT(t)
+
+	cfg := renderConfig(t, ssdlokiv1.SsdLokiSpec{
+		ChunkStoreConfig: &ssdlokiv1.ChunkStoreConfig{
+			ChunkCacheConfig: &ssdlokiv1.ChunkCacheConfig{
+				MemcachedClient: &ssdlokiv1.MemcachedClientConfig{
+					Addresses: "memcached.cache.svc:11211",
+				},
+			},
+		},
+	})
+
+	chunkCache := cfg["chunk_store_config"].(map[string]interface{})["chunk_cache_config"]
+	g.Expect(chunkCache).To(HaveKeyWithValue("memcached_client", And(
+		HaveKeyWithValue("addresses", "memcached.cache.svc:11211"),
+		HaveKeyWithValue("consistent_hash", true),
+		HaveKeyWithValue("timeout", "2000ms"),
+	)))
+}
+
+func TestLokiRuntimeConfigMap_RendersPerTenantOverrides(t *testing.T) {
+	g := NewWithT(t)
+
+	opts := Options{
+		Name:      "loki",
+		Namespace: "default",
+		Stack: ssdlokiv1.SsdLoki{
+			Spec: ssdlokiv1.SsdLokiSpec{
+				Overrides: map[string]ssdlokiv1.PerTenantLimitsConfig{
+					"team-a": {
+						IngestionRateMB:  20,
+						QueryTimeout:     "5m",
+						RejectOldSamples: ptr.To(false),
+					},
+					"team-b": {
+						MaxQuerySeries: 1000,
+					},
+				},
+			},
+		},
+	}
+	g.Expect(ApplyDefaultSettings(&opts)).To(Succeed())
+
+	cm, err := LokiRuntimeConfigMap(opts)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(cm.Name).To(Equal("loki-runtime"))
+
+	out := map[string]interface{}{}
+	g.Expect(yaml.Unmarshal([]byte(cm.Data[config.LokiRuntimeConfigFileName]), &out)).To(Succeed())
+
+	overrides := out["overrides"].(map[string]interface{})
+	g.Expect(overrides).To(HaveKeyWithValue("team-a", And(
+		HaveKeyWithValue("ingestion_rate_mb", float64(20)),
+		HaveKeyWithValue("query_timeout", "5m"),
+		HaveKeyWithValue("reject_old_samples", false),
+	)))
+	g.Expect(overrides["team-a"]).NotTo(HaveKey("max_query_series"))
+	g.Expect(overrides).To(HaveKeyWithValue("team-b", HaveKeyWithValue("max_query_series", float64(1000))))
+}
+
+func TestLokiRuntimeConfigMap_NoOverrides(t *testing.T) {
+	g := NewWithT(t)
+
+	cm, err := LokiRuntimeConfigMap(newOptions(t, nil))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	out := map[string]interface{}{}
+	g.Expect(yaml.Unmarshal([]byte(cm.Data[config.LokiRuntimeConfigFileName]), &out)).To(Succeed())
+	g.Expect(out).To(HaveKeyWithValue("overrides", BeNil()))
+}
+
+func TestLokiConfigMap_DerivesSettingsFromSize(t *testing.T) {
+	g := NewWithT(t)
+
+	cfg := renderConfig(t, ssdlokiv1.SsdLokiSpec{
+		Size: ssdlokiv1.SizeMedium,
+	})
+
+	// The medium read tier requests 2 CPU cores and the write tier 8Gi of memory.
+	g.Expect(cfg["querier"]).To(HaveKeyWithValue("max_concurrent", float64(2)))
+	g.Expect(cfg["ingester"]).To(HaveKeyWithValue("wal", HaveKeyWithValue("replay_memory_ceiling", float64(4*1024*1024*1024))))
+
+	cfg = renderConfig(t, ssdlokiv1.SsdLokiSpec{
+		Size: ssdlokiv1.SizeDemo,
+	})
+	g.Expect(cfg["common"]).To(HaveKeyWithValue("replication_factor", float64(1)))
+}
+
+func TestLokiConfigMap_S3CredentialsExpandFromEnv(t *testing.T) {
+	g := NewWithT(t)
+
+	opts := Options{
+		Name:      "loki",
+		Namespace: "default",
+		Stack: ssdlokiv1.SsdLoki{
+			Spec: ssdlokiv1.SsdLokiSpec{
+				Common: &ssdlokiv1.CommonConfig{
+					Storage: &ssdlokiv1.CommonStorage{
+						Secret: &ssdlokiv1.ObjectStorageSecretSpec{Name: "s3-creds"},
+						S3: &ssdlokiv1.S3Config{
+							BucketNames: "logs",
+							Endpoint:    "s3.amazonaws.com",
+						},
+					},
+				},
+			},
+		},
+		ObjectStorage: storage.Options{
+			SharedStore: storage.ObjectStorageTypeS3,
+			SecretName:  "s3-creds",
+			SecretSHA1:  "abc",
+		},
+	}
+	g.Expect(ApplyDefaultSettings(&opts)).To(Succeed())
+
+	cm, err := LokiConfigMap(opts)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(cm.Data[config.LokiConfigFileName]).To(ContainSubstring("access_key_id: ${AWS_ACCESS_KEY_ID}"))
+	g.Expect(cm.Data[config.LokiConfigFileName]).To(ContainSubstring("secret_access_key: ${AWS_SECRET_ACCESS_KEY}"))
+
+	objs, err := BuildAll(opts)
+	g.Expect(err).NotTo(HaveOccurred())
+	for _, obj := range objs {
+		sts, ok := obj.(*appsv1.StatefulSet)
+		if !ok {
+			continue
+		}
+		g.Expect(sts.Spec.Template.Annotations).To(HaveKeyWithValue(storage.AnnotationStorageSecretHash, "abc"))
+
+		c := sts.Spec.Template.Spec.Containers[0]
+		g.Expect(c.Args).To(ContainElement("-config.expand-env=true"))
+		g.Expect(c.Env).To(ContainElement(HaveField("Name", storage.EnvAWSAccessKeyID)))
+		g.Expect(c.Env).To(ContainElement(HaveField("Name", storage.EnvAWSAccessKeySecret)))
+	}
+}
+
+func TestLokiConfigMap_ObjectStorageGolden(t *testing.T) {
+	tt := []struct {
+		golden  string
+		storage *ssdlokiv1.CommonStorage
+		secret  string
+	}{
+		{
+			golden: "s3.yaml",
+			storage: &ssdlokiv1.CommonStorage{
+				S3: &ssdlokiv1.S3Config{
+					BucketNames:      "logs",
+					Endpoint:         "minio.storage.svc:9000",
+					Insecure:         true,
+					S3ForcePathStyle: true,
+				},
+			},
+			secret: "creds",
+		},
+		{
+			golden: "gcs.yaml",
+			storage: &ssdlokiv1.CommonStorage{
+				GCS: &ssdlokiv1.GCSConfig{BucketName: "logs"},
+			},
+			secret: "creds",
+		},
+		{
+			golden: "azure.yaml",
+			storage: &ssdlokiv1.CommonStorage{
+				Azure: &ssdlokiv1.AzureConfig{
+					ContainerName:  "logs",
+					EndpointSuffix: "blob.core.usgovcloudapi.net",
+				},
+			},
+			secret: "creds",
+		},
+		{
+			golden: "swift.yaml",
+			storage: &ssdlokiv1.CommonStorage{
+				Swift: &ssdlokiv1.SwiftConfig{
+					AuthURL:           "https://keystone.example.com/v3",
+					ContainerName:     "logs",
+					UserDomainName:    "default",
+					ProjectName:       "logging",
+					ProjectDomainName: "default",
+					RegionName:        "RegionOne",
+				},
+			},
+			secret: "creds",
+		},
+		{
+			golden: "filesystem.yaml",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.golden, func(t *testing.T) {
+			g := NewWithT(t)
+
+			opts := Options{
+				Name:      "loki",
+				Namespace: "default",
+				Stack: ssdlokiv1.SsdLoki{
+					Spec: ssdlokiv1.SsdLokiSpec{
+						Common: &ssdlokiv1.CommonConfig{Storage: tc.storage},
+					},
+				},
+				ObjectStorage: storage.Options{SecretName: tc.secret},
+			}
+			g.Expect(ApplyDefaultSettings(&opts)).To(Succeed())
+
+			cm, err := LokiConfigMap(opts)
+			g.Expect(err).NotTo(HaveOccurred())
+
+			cfg := map[string]interface{}{}
+			g.Expect(yaml.Unmarshal([]byte(cm.Data[config.LokiConfigFileName]), &cfg)).To(Succeed())
+
+			schemas := cfg["schema_config"].(map[string]interface{})["configs"].([]interface{})
+			got := map[string]interface{}{
+				"common": map[string]interface{}{
+					"storage": cfg["common"].(map[string]interface{})["storage"],
+				},
+				"object_store": schemas[0].(map[string]interface{})["object_store"],
+			}
+
+			b, err := os.ReadFile(filepath.Join("testdata", "storage", tc.golden))
+			g.Expect(err).NotTo(HaveOccurred())
+			want := map[string]interface{}{}
+			g.Expect(yaml.Unmarshal(b, &want)).To(Succeed())
+
+			g.Expect(got).To(Equal(want))
+		})
+	}
+}
+
+func TestApplyDefaultSettings_RejectsSeveralStorageBackends(t *testing.T) {
+	g := NewWithT(t)
+
+	opts := Options{
+		Name:      "loki",
+		Namespace: "default",
+		Stack: ssdlokiv1.SsdLoki{
+			Spec: ssdlokiv1.SsdLokiSpec{
+				Common: &ssdlokiv1.CommonConfig{
+					Storage: &ssdlokiv1.CommonStorage{
+						S3:  &ssdlokiv1.S3Config{BucketNames: "logs"},
+						GCS: &ssdlokiv1.GCSConfig{BucketName: "logs"},
+					},
+				},
+			},
+		},
+	}
+	g.Expect(ApplyDefaultSettings(&opts)).NotTo(Succeed())
+}
@@ -3,13 +3,15 @@ package manifests
 import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/ssd-loki/loki-operator/internal/manifests/internal/config"
 )
 
-func lokiMinioConfigmap(name string, config string) *corev1.ConfigMap {
+func lokiConfigmap(name, namespace string, config string) *corev1.ConfigMap {
 	return &corev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      name,
-			Namespace: "default",
+			Namespace: namespace,
 		},
 		Data: map[string]string{
 			"config.yaml": config,
@@ -17,14 +19,14 @@ func lokiMinioConfigmap(name string, config string) *corev1.ConfigMap {
 	}
 }
 
-func lokiConfigmap(name string, config string) *corev1.ConfigMap {
+func lokiRuntimeConfigmap(name, namespace string, runtimeConfig string) *corev1.ConfigMap {
 	return &corev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      name,
-			Namespace: "default",
+			Namespace: namespace,
 		},
 		Data: map[string]string{
-			"config.yaml": config,
+			config.LokiRuntimeConfigFileName: runtimeConfig,
 		},
 	}
 }
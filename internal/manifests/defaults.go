@@ -0,0 +1,171 @@
+package manifests
+
+import (
+	"fmt"
+	"path"
+	"reflect"
+
+	"github.com/ViaQ/logerr/kverrors"
+
+	ssdlokiv1 "github.com/ssd-loki/loki-operator/api/v1"
+	"github.com/ssd-loki/loki-operator/internal/manifests/internal"
+	"github.com/ssd-loki/loki-operator/internal/manifests/storage"
+)
+
+// applySpecDefaults fills every field left unset in the stack spec with
+// the preset of the selected size, the service addresses of this stack and
+// the default Loki settings, in that order.
+func applySpecDefaults(opts *Options) error {
+	spec := opts.Stack.Spec.DeepCopy()
+
+	if spec.Size != "" {
+		preset, ok := internal.StackSizeTable[spec.Size]
+		if !ok {
+			return kverrors.New("unknown stack size", "size", spec.Size)
+		}
+		mergeDefaults(spec, *preset.DeepCopy())
+	}
+
+	mergeDefaults(spec, addressDefaults(*opts))
+	mergeDefaults(spec, internal.DefaultSsdLokiSpec())
+	applyCacheAddresses(*opts, spec)
+
+	// Behind the gateway Loki trusts the tenant header it sets, so requests
+	// without one must be rejected.
+	if gatewayEnabled(opts.Stack) {
+		spec.AuthEnabled = true
+	}
+
+	if opts.ObjectStorage.SharedStore == "" {
+		t, err := storage.TypeOf(spec.Common.Storage)
+		if err != nil {
+			return err
+		}
+		opts.ObjectStorage.SharedStore = t
+	}
+
+	if opts.ObjectStorage.SharedStore == storage.ObjectStorageTypeFilesystem {
+		applyFilesystemDefaults(spec.Common)
+	}
+
+	for i := range spec.SchemaConfig.Configs {
+		if spec.SchemaConfig.Configs[i].ObjectStore == "" {
+			spec.SchemaConfig.Configs[i].ObjectStore = string(opts.ObjectStorage.SharedStore)
+		}
+	}
+
+	opts.Stack.Spec = *spec
+	return nil
+}
+
+// DefaultSpec fills every field left unset in the spec with the default Loki
+// settings that depend neither on the stack name and namespace nor on its
+// size. It is used to persist the defaults on admission.
+//
+// The replication factor and the tier template are left to the size preset
+// applied on reconcile, so that changing spec.size later still takes effect.
+func DefaultSpec(spec *ssdlokiv1.SsdLokiSpec) {
+	defaults := internal.DefaultSsdLokiSpec()
+	defaults.Template = nil
+	defaults.Common.ReplicationFactor = 0
+
+	mergeDefaults(spec, defaults)
+}
+
+// addressDefaults returns the parts of the spec pointing at the services of this stack.
+func addressDefaults(opts Options) ssdlokiv1.SsdLokiSpec {
+	backendHeadless := fqdn(headlessServiceName(BackendName(opts.Name)), opts.Namespace)
+
+	return ssdlokiv1.SsdLokiSpec{
+		BloomBuild: &ssdlokiv1.BloomBuild{
+			Builder: &ssdlokiv1.BloomBuildBuilder{
+				PlannerAddress: fmt.Sprintf("%s:%d", backendHeadless, opts.lokiGRPCPort()),
+			},
+		},
+		BloomGateway: &ssdlokiv1.BloomGateway{
+			Client: &ssdlokiv1.BloomGatewayClient{
+				Addresses: fmt.Sprintf("dnssrvnoa+_grpc._tcp.%s", backendHeadless),
+			},
+		},
+		Common: &ssdlokiv1.CommonConfig{
+			CompactorAddress: fmt.Sprintf("%s://%s:%d", httpScheme(&opts.Stack), fqdn(BackendName(opts.Name), opts.Namespace), opts.lokiHTTPPort()),
+		},
+		Memberlist: &ssdlokiv1.MemberlistConfig{
+			JoinMembers: []string{memberListName(opts.Name)},
+		},
+		StorageConfig: &ssdlokiv1.StorageConfig{
+			TSDBShipper: &ssdlokiv1.TSDBShipperConfig{
+				IndexGatewayClient: &ssdlokiv1.IndexGatewayClientConfig{
+					ServerAddress: fmt.Sprintf("dns+%s:%d", backendHeadless, opts.lokiGRPCPort()),
+				},
+			},
+		},
+	}
+}
+
+// applyFilesystemDefaults places the chunks and rules below the path prefix
+// unless other directories are configured.
+func applyFilesystemDefaults(common *ssdlokiv1.CommonConfig) {
+	if common.Storage == nil {
+		common.Storage = &ssdlokiv1.CommonStorage{}
+	}
+	if common.Storage.Filesystem == nil {
+		common.Storage.Filesystem = &ssdlokiv1.FilesystemConfig{}
+	}
+
+	fs := common.Storage.Filesystem
+	if fs.ChunksDirectory == "" {
+		fs.ChunksDirectory = path.Join(common.PathPrefix, "chunks")
+	}
+	if fs.RulesDirectory == "" {
+		fs.RulesDirectory = path.Join(common.PathPrefix, "rules")
+	}
+}
+
+// mergeDefaults fills every unset field of dst with the value of the same
+// field in defaults. Nested structs and pointers to structs are merged field
+// by field, while any other value that is already set, including a pointer to
+// false or zero, is kept as-is.
+func mergeDefaults(dst *ssdlokiv1.SsdLokiSpec, defaults ssdlokiv1.SsdLokiSpec) {
+	mergeValue(reflect.ValueOf(dst).Elem(), reflect.ValueOf(defaults))
+}
+
+func mergeValue(dst, src reflect.Value) {
+	switch dst.Kind() {
+	case reflect.Struct:
+		if !hasOnlyExportedFields(dst.Type()) {
+			if dst.IsZero() {
+				dst.Set(src)
+			}
+			return
+		}
+		for i := 0; i < dst.NumField(); i++ {
+			mergeValue(dst.Field(i), src.Field(i))
+		}
+	case reflect.Ptr:
+		switch {
+		case src.IsNil():
+		case dst.IsNil():
+			dst.Set(src)
+		case dst.Elem().Kind() == reflect.Struct:
+			mergeValue(dst.Elem(), src.Elem())
+		}
+	case reflect.Slice, reflect.Map:
+		if dst.Len() == 0 && !src.IsNil() {
+			dst.Set(src)
+		}
+	default:
+		if dst.IsZero() {
+			dst.Set(src)
+		}
+	}
+}
+
+func hasOnlyExportedFields(t reflect.Type) bool {
+	for i := 0; i < t.NumField(); i++ {
+		if !t.Field(i).IsExported() {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,491 @@
+package manifests
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/ViaQ/logerr/kverrors"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ssdlokiv1 "github.com/ssd-loki/loki-operator/api/v1"
+	"github.com/ssd-loki/loki-operator/internal/manifests/internal/gateway"
+)
+
+const (
+	gatewayContainerName    = "gateway"
+	gatewayHTTPPortName     = "public"
+	gatewayHTTPPort         = 8080
+	gatewayInternalPortName = "metrics"
+	gatewayInternalPort     = 8081
+
+	gatewayConfigVolumeName  = "rbac"
+	gatewayConfigMountDir    = "/etc/ssd-loki-gateway/rbac"
+	gatewayTenantsVolumeName = "tenants"
+	gatewayTenantsMountDir   = "/etc/ssd-loki-gateway/tenants"
+	gatewayTLSVolumeName     = "tls"
+	gatewayTLSMountDir       = "/var/run/tls/http/server"
+	gatewayTenantCAMountDir  = "/var/run/tenants-ca"
+
+	defaultGatewayImage = "quay.io/observatorium/api:latest"
+
+	// AnnotationGatewayConfigHash is the pod annotation holding the hash of the
+	// tenants and rbac config of the gateway. Changing it rolls the gateway pods.
+	AnnotationGatewayConfigHash = "ssd-loki.ssd-loki.com/gateway-config-hash"
+)
+
+// GatewayName is the name of the gateway Deployment, Service, ConfigMap and Secret of a stack.
+func GatewayName(stackName string) string {
+	return fmt.Sprintf("%s-gateway", stackName)
+}
+
+// TenantCAPath returns the path the CA bundle of a gateway tenant is mounted at.
+func TenantCAPath(tenantName, caKey string) string {
+	return path.Join(gatewayTenantCAMountDir, tenantName, caKey)
+}
+
+// gatewayEnabled reports whether the stack runs the authenticating gateway.
+func gatewayEnabled(stack ssdlokiv1.SsdLoki) bool {
+	return stack.Spec.Gateway != nil && stack.Spec.Gateway.Enabled
+}
+
+// BuildGateway builds the authenticating gateway in front of the read and write
+// tiers. The gateway serves the Loki API of each tenant under
+// /api/logs/v1/<tenant>/, forwards pushes to the write tier and queries and tails
+// to the read tier, and sets the tenant header after authenticating the request.
+func BuildGateway(opts Options) ([]client.Object, error) {
+	if !gatewayEnabled(opts.Stack) {
+		return nil, nil
+	}
+
+	gwOpts, err := gatewayOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	tenants, rbac, err := gateway.Build(gwOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	cm := NewGatewayConfigMap(opts, rbac)
+	secret := NewGatewaySecret(opts, tenants)
+	deployment := NewGatewayDeployment(opts, configHash(string(tenants), string(rbac)))
+
+	return []client.Object{
+		cm,
+		secret,
+		deployment,
+		NewGatewayService(opts),
+	}, nil
+}
+
+// obsoleteGatewayObjects returns the gateway objects if the gateway is disabled.
+func obsoleteGatewayObjects(opts Options) []client.Object {
+	if gatewayEnabled(opts.Stack) {
+		return nil
+	}
+
+	meta := metav1.ObjectMeta{Name: GatewayName(opts.Name), Namespace: opts.Namespace}
+	return []client.Object{
+		&appsv1.Deployment{ObjectMeta: meta},
+		&corev1.Service{ObjectMeta: meta},
+		&corev1.ConfigMap{ObjectMeta: meta},
+		&corev1.Secret{ObjectMeta: meta},
+	}
+}
+
+// gatewayOptions joins the tenants of the spec with the credentials read from
+// their Secrets.
+func gatewayOptions(opts Options) (gateway.Options, error) {
+	secrets := map[string]*TenantSecrets{}
+	for _, s := range opts.Tenants.Secrets {
+		secrets[s.TenantName] = s
+	}
+
+	spec := opts.Stack.Spec.Gateway.Tenants
+	if spec == nil {
+		return gateway.Options{}, nil
+	}
+
+	gwOpts := gateway.Options{
+		Authorization: spec.Authorization,
+	}
+	for _, a := range spec.Authentication {
+		t := gateway.Tenant{
+			Name: a.TenantName,
+			ID:   a.TenantID,
+		}
+
+		switch {
+		case a.OIDC != nil:
+			s, ok := secrets[a.TenantName]
+			if !ok || s.OIDCSecret == nil {
+				return gateway.Options{}, kverrors.New("missing oidc secret for tenant", "tenant", a.TenantName)
+			}
+			t.OIDC = &gateway.OIDC{
+				ClientID:      s.OIDCSecret.ClientID,
+				ClientSecret:  s.OIDCSecret.ClientSecret,
+				IssuerURL:     a.OIDC.IssuerURL,
+				RedirectURL:   a.OIDC.RedirectURL,
+				UsernameClaim: a.OIDC.UsernameClaim,
+				GroupClaim:    a.OIDC.GroupClaim,
+			}
+			if ca := TenantCA(a); ca != nil {
+				t.OIDC.IssuerCAPath = TenantCAPath(a.TenantName, CAKey(ca))
+			}
+		case a.MTLS != nil:
+			ca := TenantCA(a)
+			if ca == nil {
+				return gateway.Options{}, kverrors.New("missing mtls ca for tenant", "tenant", a.TenantName)
+			}
+			t.MTLS = &gateway.MTLS{
+				CAPath: TenantCAPath(a.TenantName, CAKey(ca)),
+			}
+		}
+
+		gwOpts.Tenants = append(gwOpts.Tenants, t)
+	}
+
+	return gwOpts, nil
+}
+
+// TenantCA returns the CA ConfigMap a tenant's OIDC provider or client
+// certificates are verified with, or nil if it references none.
+func TenantCA(a ssdlokiv1.AuthenticationSpec) *ssdlokiv1.CASpec {
+	switch {
+	case a.OIDC != nil:
+		return a.OIDC.IssuerCA
+	case a.MTLS != nil:
+		return a.MTLS.CA
+	}
+	return nil
+}
+
+// NewGatewayDeployment creates the Deployment of the gateway. configSHA1 is the
+// hash of its tenants and rbac config.
+func NewGatewayDeployment(opts Options, configSHA1 string) *appsv1.Deployment {
+	l := commonLabels(opts.Name, ComponentGateway)
+	spec := opts.Stack.Spec.Gateway
+
+	scheme := httpScheme(&opts.Stack)
+	readURL := fmt.Sprintf("%s://%s:%d", scheme, fqdn(ReadName(opts.Name), opts.Namespace), opts.lokiHTTPPort())
+	writeURL := fmt.Sprintf("%s://%s:%d", scheme, fqdn(WriteName(opts.Name), opts.Namespace), opts.lokiHTTPPort())
+
+	container := corev1.Container{
+		Name:            gatewayContainerName,
+		Image:           opts.GatewayImage,
+		ImagePullPolicy: corev1.PullIfNotPresent,
+		Args: []string{
+			fmt.Sprintf("--web.listen=0.0.0.0:%d", gatewayHTTPPort),
+			fmt.Sprintf("--web.internal.listen=0.0.0.0:%d", gatewayInternalPort),
+			"--log.level=warn",
+			fmt.Sprintf("--logs.read.endpoint=%s", readURL),
+			fmt.Sprintf("--logs.tail.endpoint=%s", readURL),
+			fmt.Sprintf("--logs.write.endpoint=%s", writeURL),
+			fmt.Sprintf("--server.read-timeout=%s", opts.Timeouts.Gateway.ReadTimeout),
+			fmt.Sprintf("--server.write-timeout=%s", opts.Timeouts.Gateway.WriteTimeout),
+			fmt.Sprintf("--logs.write-timeout=%s", opts.Timeouts.Gateway.UpstreamWriteTimeout),
+			fmt.Sprintf("--rbac.config=%s", path.Join(gatewayConfigMountDir, gateway.RBACFileName)),
+			fmt.Sprintf("--tenants.config=%s", path.Join(gatewayTenantsMountDir, gateway.TenantsFileName)),
+		},
+		Ports: []corev1.ContainerPort{
+			{
+				Name:          gatewayHTTPPortName,
+				ContainerPort: gatewayHTTPPort,
+				Protocol:      protocolTCP,
+			},
+			{
+				Name:          gatewayInternalPortName,
+				ContainerPort: gatewayInternalPort,
+				Protocol:      protocolTCP,
+			},
+		},
+		SecurityContext: &corev1.SecurityContext{
+			AllowPrivilegeEscalation: ptr.To(false),
+			Capabilities: &corev1.Capabilities{
+				Drop: []corev1.Capability{"ALL"},
+			},
+			ReadOnlyRootFilesystem: ptr.To(true),
+		},
+		LivenessProbe: &corev1.Probe{
+			ProbeHandler: corev1.ProbeHandler{
+				HTTPGet: &corev1.HTTPGetAction{
+					Path: "/live",
+					Port: intstr.FromInt32(gatewayInternalPort),
+				},
+			},
+			TimeoutSeconds:   2,
+			PeriodSeconds:    30,
+			FailureThreshold: 10,
+		},
+		ReadinessProbe: &corev1.Probe{
+			ProbeHandler: corev1.ProbeHandler{
+				HTTPGet: &corev1.HTTPGetAction{
+					Path: "/ready",
+					Port: intstr.FromInt32(gatewayInternalPort),
+				},
+			},
+			TimeoutSeconds:   1,
+			PeriodSeconds:    5,
+			FailureThreshold: 12,
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			{
+				Name:      gatewayConfigVolumeName,
+				ReadOnly:  true,
+				MountPath: gatewayConfigMountDir,
+			},
+			{
+				Name:      gatewayTenantsVolumeName,
+				ReadOnly:  true,
+				MountPath: gatewayTenantsMountDir,
+			},
+		},
+	}
+	if spec.Resources != nil {
+		container.Resources = *spec.Resources.DeepCopy()
+	}
+
+	podSpec := corev1.PodSpec{
+		ServiceAccountName:           serviceAccountName(opts.Name),
+		AutomountServiceAccountToken: ptr.To(false),
+		SecurityContext: &corev1.PodSecurityContext{
+			RunAsNonRoot: ptr.To(true),
+		},
+		Containers: []corev1.Container{container},
+		Volumes: []corev1.Volume{
+			{
+				Name: gatewayConfigVolumeName,
+				VolumeSource: corev1.VolumeSource{
+					ConfigMap: &corev1.ConfigMapVolumeSource{
+						LocalObjectReference: corev1.LocalObjectReference{
+							Name: GatewayName(opts.Name),
+						},
+					},
+				},
+			},
+			{
+				Name: gatewayTenantsVolumeName,
+				VolumeSource: corev1.VolumeSource{
+					Secret: &corev1.SecretVolumeSource{
+						SecretName: GatewayName(opts.Name),
+					},
+				},
+			},
+		},
+	}
+
+	configureGatewayTLS(&podSpec, opts)
+	configureGatewayUpstreamTLS(&podSpec, opts)
+	configureGatewayTenantCAs(&podSpec, opts)
+
+	replicas := int32(1)
+	if spec.Replicas != nil {
+		replicas = *spec.Replicas
+	}
+
+	return &appsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Deployment",
+			APIVersion: appsv1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      GatewayName(opts.Name),
+			Namespace: opts.Namespace,
+			Labels:    l,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: ptr.To(replicas),
+			Selector: &metav1.LabelSelector{
+				MatchLabels: l,
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: l,
+					Annotations: map[string]string{
+						AnnotationGatewayConfigHash: configSHA1,
+					},
+				},
+				Spec: podSpec,
+			},
+			Strategy: appsv1.DeploymentStrategy{
+				Type: appsv1.RollingUpdateDeploymentStrategyType,
+			},
+			RevisionHistoryLimit: ptr.To(int32(10)),
+		},
+	}
+}
+
+// configureGatewayTLS makes the gateway serve HTTPS with the certificate of the
+// Secret referenced in the spec.
+func configureGatewayTLS(podSpec *corev1.PodSpec, opts Options) {
+	tls := opts.Stack.Spec.Gateway.TLS
+	if tls == nil {
+		return
+	}
+
+	podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+		Name: gatewayTLSVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: tls.SecretName,
+			},
+		},
+	})
+
+	c := &podSpec.Containers[0]
+	c.VolumeMounts = append(c.VolumeMounts, corev1.VolumeMount{
+		Name:      gatewayTLSVolumeName,
+		ReadOnly:  true,
+		MountPath: gatewayTLSMountDir,
+	})
+	c.Args = append(c.Args,
+		fmt.Sprintf("--tls.server.cert-file=%s", path.Join(gatewayTLSMountDir, corev1.TLSCertKey)),
+		fmt.Sprintf("--tls.server.key-file=%s", path.Join(gatewayTLSMountDir, corev1.TLSPrivateKeyKey)),
+	)
+	if opts.TLSProfile.MinTLSVersion != "" {
+		c.Args = append(c.Args, fmt.Sprintf("--tls.min-version=%s", opts.TLSProfile.MinTLSVersion))
+	}
+	if len(opts.TLSProfile.Ciphers) > 0 {
+		c.Args = append(c.Args, fmt.Sprintf("--tls.cipher-suites=%s", opts.TLSCipherSuites()))
+	}
+}
+
+// configureGatewayUpstreamTLS makes the gateway verify the certificates of the
+// read and write tiers if internal TLS is enabled.
+func configureGatewayUpstreamTLS(podSpec *corev1.PodSpec, opts Options) {
+	if !InternalTLSEnabled(&opts.Stack) {
+		return
+	}
+
+	podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+		Name: tlsCAVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: opts.Stack.Spec.TLS.CA.CA,
+				},
+			},
+		},
+	})
+
+	c := &podSpec.Containers[0]
+	c.VolumeMounts = append(c.VolumeMounts, corev1.VolumeMount{
+		Name:      tlsCAVolumeName,
+		ReadOnly:  true,
+		MountPath: tlsCAMountDir,
+	})
+	c.Args = append(c.Args, fmt.Sprintf("--logs.tls.ca-file=%s", internalCAPath(opts)))
+}
+
+// configureGatewayTenantCAs mounts the CA bundles the OIDC providers and client
+// certificates of the tenants are verified with.
+func configureGatewayTenantCAs(podSpec *corev1.PodSpec, opts Options) {
+	spec := opts.Stack.Spec.Gateway.Tenants
+	if spec == nil {
+		return
+	}
+
+	c := &podSpec.Containers[0]
+	for i, a := range spec.Authentication {
+		ca := TenantCA(a)
+		if ca == nil {
+			continue
+		}
+
+		name := fmt.Sprintf("tenant-ca-%d", i)
+		podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+			Name: name,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: ca.CA,
+					},
+				},
+			},
+		})
+		c.VolumeMounts = append(c.VolumeMounts, corev1.VolumeMount{
+			Name:      name,
+			ReadOnly:  true,
+			MountPath: path.Join(gatewayTenantCAMountDir, a.TenantName),
+		})
+	}
+}
+
+// NewGatewayService creates the Service clients send their requests to.
+func NewGatewayService(opts Options) *corev1.Service {
+	l := commonLabels(opts.Name, ComponentGateway)
+
+	return &corev1.Service{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Service",
+			APIVersion: corev1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      GatewayName(opts.Name),
+			Namespace: opts.Namespace,
+			Labels:    l,
+		},
+		Spec: corev1.ServiceSpec{
+			Type: corev1.ServiceTypeClusterIP,
+			Ports: []corev1.ServicePort{
+				{
+					Name:       gatewayHTTPPortName,
+					Port:       gatewayHTTPPort,
+					Protocol:   protocolTCP,
+					TargetPort: intstr.FromString(gatewayHTTPPortName),
+				},
+				{
+					Name:       gatewayInternalPortName,
+					Port:       gatewayInternalPort,
+					Protocol:   protocolTCP,
+					TargetPort: intstr.FromString(gatewayInternalPortName),
+				},
+			},
+			Selector: l,
+		},
+	}
+}
+
+// NewGatewayConfigMap creates the ConfigMap holding the rbac config of the gateway.
+func NewGatewayConfigMap(opts Options, rbac []byte) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "ConfigMap",
+			APIVersion: corev1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      GatewayName(opts.Name),
+			Namespace: opts.Namespace,
+			Labels:    commonLabels(opts.Name, ComponentGateway),
+		},
+		Data: map[string]string{
+			gateway.RBACFileName: string(rbac),
+		},
+	}
+}
+
+// NewGatewaySecret creates the Secret holding the tenants config of the gateway,
+// which contains the OIDC client secrets of the tenants.
+func NewGatewaySecret(opts Options, tenants []byte) *corev1.Secret {
+	return &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Secret",
+			APIVersion: corev1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      GatewayName(opts.Name),
+			Namespace: opts.Namespace,
+			Labels:    commonLabels(opts.Name, ComponentGateway),
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{
+			gateway.TenantsFileName: tenants,
+		},
+	}
+}
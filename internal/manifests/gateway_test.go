@@ -0,0 +1,180 @@
+package manifests
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+
+	ssdlokiv1 "github.com/ssd-loki/loki-operator/api/v1"
+	"github.com/ssd-loki/loki-operator/internal/manifests/internal/config"
+	"github.com/ssd-loki/loki-operator/internal/manifests/internal/gateway"
+)
+
+func gatewayOptionsFor(t *testing.T) Options {
+	opts := newOptions(t, func(spec *ssdlokiv1.SsdLokiSpec) {
+		spec.Gateway = &ssdlokiv1.GatewaySpec{
+			Enabled: true,
+			TLS:     &ssdlokiv1.GatewayTLSSpec{SecretName: "gateway-tls"},
+			Tenants: &ssdlokiv1.TenantsSpec{
+				Authentication: []ssdlokiv1.AuthenticationSpec{
+					{
+						TenantName: "team-a",
+						TenantID:   "a",
+						OIDC: &ssdlokiv1.OIDCSpec{
+							Secret:    &ssdlokiv1.TenantSecretSpec{Name: "team-a-oidc"},
+							IssuerURL: "https://dex.example.com",
+							IssuerCA:  &ssdlokiv1.CASpec{CA: "dex-ca"},
+						},
+					},
+					{
+						TenantName: "team-b",
+						TenantID:   "b",
+						MTLS: &ssdlokiv1.MTLSSpec{
+							CA: &ssdlokiv1.CASpec{CA: "team-b-ca", CAKey: "ca.crt"},
+						},
+					},
+				},
+				Authorization: &ssdlokiv1.AuthorizationSpec{
+					Roles: []ssdlokiv1.RoleSpec{
+						{
+							Name:        "read-write",
+							Resources:   []string{"logs"},
+							Tenants:     []string{"team-a", "team-b"},
+							Permissions: []ssdlokiv1.PermissionType{ssdlokiv1.PermissionRead, ssdlokiv1.PermissionWrite},
+						},
+					},
+					RoleBindings: []ssdlokiv1.RoleBindingsSpec{
+						{
+							Name:     "admins",
+							Subjects: []ssdlokiv1.Subject{{Name: "admins", Kind: ssdlokiv1.Group}},
+							Roles:    []string{"read-write"},
+						},
+					},
+				},
+			},
+		}
+	})
+	opts.Tenants = Tenants{
+		Secrets: []*TenantSecrets{
+			{
+				TenantName: "team-a",
+				OIDCSecret: &OIDCSecret{ClientID: "id", ClientSecret: "secret"},
+			},
+		},
+	}
+	return opts
+}
+
+func gatewayObjects(t *testing.T, opts Options) (*appsv1.Deployment, *corev1.ConfigMap, *corev1.Secret) {
+	objs, err := BuildGateway(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		dpl    *appsv1.Deployment
+		cm     *corev1.ConfigMap
+		secret *corev1.Secret
+	)
+	for _, obj := range objs {
+		switch o := obj.(type) {
+		case *appsv1.Deployment:
+			dpl = o
+		case *corev1.ConfigMap:
+			cm = o
+		case *corev1.Secret:
+			secret = o
+		}
+	}
+	return dpl, cm, secret
+}
+
+func TestBuildGateway_Disabled(t *testing.T) {
+	g := NewWithT(t)
+
+	opts := gatewayOptionsFor(t)
+	opts.Stack.Spec.Gateway.Enabled = false
+
+	objs, err := BuildGateway(opts)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(objs).To(BeEmpty())
+	g.Expect(ObsoleteObjects(opts)).To(ContainElement(HaveField("ObjectMeta.Name", "loki-gateway")))
+}
+
+func TestBuildGateway_RoutesToReadAndWrite(t *testing.T) {
+	g := NewWithT(t)
+
+	dpl, _, _ := gatewayObjects(t, gatewayOptionsFor(t))
+	g.Expect(dpl).NotTo(BeNil())
+
+	args := dpl.Spec.Template.Spec.Containers[0].Args
+	g.Expect(args).To(ContainElements(
+		"--logs.read.endpoint=http://loki-read.ns.svc.cluster.local:3100",
+		"--logs.tail.endpoint=http://loki-read.ns.svc.cluster.local:3100",
+		"--logs.write.endpoint=http://loki-write.ns.svc.cluster.local:3100",
+		"--tls.server.cert-file=/var/run/tls/http/server/tls.crt",
+		"--tls.server.key-file=/var/run/tls/http/server/tls.key",
+		"--server.read-timeout=1m0s",
+		"--server.write-timeout=8m0s",
+		"--logs.write-timeout=6m0s",
+	))
+	g.Expect(dpl.Spec.Template.Annotations).To(HaveKey(AnnotationGatewayConfigHash))
+}
+
+func TestBuildGateway_Tenants(t *testing.T) {
+	g := NewWithT(t)
+
+	dpl, cm, secret := gatewayObjects(t, gatewayOptionsFor(t))
+
+	var tenants struct {
+		Tenants []struct {
+			Name string `json:"name"`
+			ID   string `json:"id"`
+			OIDC *struct {
+				ClientID     string `json:"clientID"`
+				ClientSecret string `json:"clientSecret"`
+				IssuerURL    string `json:"issuerURL"`
+				IssuerCAPath string `json:"issuerCAPath"`
+			} `json:"oidc"`
+			MTLS *struct {
+				CAPath string `json:"caPath"`
+			} `json:"mTLS"`
+		} `json:"tenants"`
+	}
+	g.Expect(yaml.Unmarshal(secret.Data[gateway.TenantsFileName], &tenants)).To(Succeed())
+	g.Expect(tenants.Tenants).To(HaveLen(2))
+
+	a := tenants.Tenants[0]
+	g.Expect(a.ID).To(Equal("a"))
+	g.Expect(a.OIDC).NotTo(BeNil())
+	g.Expect(a.OIDC.ClientID).To(Equal("id"))
+	g.Expect(a.OIDC.ClientSecret).To(Equal("secret"))
+	g.Expect(a.OIDC.IssuerCAPath).To(Equal("/var/run/tenants-ca/team-a/service-ca.crt"))
+
+	b := tenants.Tenants[1]
+	g.Expect(b.ID).To(Equal("b"))
+	g.Expect(b.MTLS).NotTo(BeNil())
+	g.Expect(b.MTLS.CAPath).To(Equal("/var/run/tenants-ca/team-b/ca.crt"))
+
+	var rbac map[string]interface{}
+	g.Expect(yaml.Unmarshal([]byte(cm.Data[gateway.RBACFileName]), &rbac)).To(Succeed())
+	g.Expect(rbac["roles"]).To(HaveLen(1))
+	g.Expect(rbac["roleBindings"]).To(HaveLen(1))
+
+	var mounts []string
+	for _, m := range dpl.Spec.Template.Spec.Containers[0].VolumeMounts {
+		mounts = append(mounts, m.MountPath)
+	}
+	g.Expect(mounts).To(ContainElements("/var/run/tenants-ca/team-a", "/var/run/tenants-ca/team-b"))
+}
+
+func TestBuildGateway_EnablesAuth(t *testing.T) {
+	g := NewWithT(t)
+
+	cm, err := LokiConfigMap(gatewayOptionsFor(t))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(cm.Data[config.LokiConfigFileName]).To(ContainSubstring("auth_enabled: true"))
+}
@@ -7,14 +7,16 @@ import (
 	"time"
 
 	ssdlokiv1 "github.com/ssd-loki/loki-operator/api/v1"
+	"github.com/ssd-loki/loki-operator/internal/manifests/storage"
 )
 
 // Options is used to render the loki-config.yaml file template
 type Options struct {
 	Stack ssdlokiv1.SsdLoki
-	Gates configv1.FeatureGates
 	TLS   TLSOptions
 
+	ObjectStorage storage.Options
+
 	Namespace             string
 	Name                  string
 	BackEnd               Address
@@ -23,20 +25,24 @@ type Options struct {
 	StorageDirectory      string
 	MaxConcurrent         MaxConcurrent
 	WriteAheadLog         WriteAheadLog
+	ZoneAwarenessEnabled  bool
 	EnableRemoteReporting bool
 	Shippers              []string
 
-	ObjectStorage storage.Options
-
 	HTTPTimeouts HTTPTimeoutConfig
 
+	Rules        RulesOptions
+	AlertManager *AlertManagerConfig
+	RemoteWrite  *RemoteWriteConfig
+
 	Retention RetentionOptions
 
 	Overrides map[string]LokiOverrides
 }
 
+// LokiOverrides defines the per-tenant settings rendered into the runtime config.
 type LokiOverrides struct {
-	Limits lokiv1.PerTenantLimitsTemplateSpec
+	Limits ssdlokiv1.PerTenantLimitsConfig
 	Ruler  RulerOverrides
 }
 
@@ -44,6 +50,16 @@ type RulerOverrides struct {
 	AlertManager *AlertManagerConfig
 }
 
+// RulesOptions configures the ruler to load the rule files mounted from the
+// rules ConfigMaps. It takes precedence over the ruler storage of the spec.
+type RulesOptions struct {
+	Enabled bool
+	// Directory holds a directory of rule files per tenant.
+	Directory string
+	// ScratchDirectory is where the ruler writes the rule files it evaluates.
+	ScratchDirectory string
+}
+
 // Address FQDN and port for a k8s service.
 type Address struct {
 	// Protocol is optional
@@ -115,6 +131,7 @@ type TLSConfig struct {
 type RemoteWriteConfig struct {
 	Enabled        bool
 	RefreshPeriod  string
+	WALDirectory   string
 	Client         *RemoteWriteClientConfig
 	Queue          *RemoteWriteQueueConfig
 	RelabelConfigs []RelabelConfig
@@ -173,13 +190,13 @@ func (r RelabelConfig) SourceLabelsString() string {
 	return sb.String()
 }
 
-// SeparatorString returns the user-defined separator or per default semicolon.
+// SeparatorString returns the quoted user-defined separator or per default semicolon.
 func (r RelabelConfig) SeparatorString() string {
 	if r.Separator == "" {
-		return `""`
+		return `";"`
 	}
 
-	return r.Separator
+	return fmt.Sprintf("%q", r.Separator)
 }
 
 // MaxConcurrent for concurrent query processing.
@@ -214,43 +231,56 @@ func (w WriteAheadLog) ReplayMemoryCeiling() string {
 type RetentionOptions struct {
 	Enabled           bool
 	DeleteWorkerCount uint
+	// DeleteRequestStore is the object store the log deletion requests are kept in.
+	DeleteRequestStore string
 }
 
+// TLSOptions configures TLS for the HTTP and gRPC servers of all components and
+// for the clients between them.
 type TLSOptions struct {
+	Enabled       bool
 	Ciphers       []string
 	MinTLSVersion string
 	Paths         TLSFilePaths
 	ServerNames   TLSServerNames
 }
 
+// CipherSuitesString returns the cipher suites joined with a comma.
 func (o TLSOptions) CipherSuitesString() string {
 	return strings.Join(o.Ciphers, ",")
 }
 
+// TLSFilePaths are the paths of the CA bundle and the certificates mounted into the pods.
 type TLSFilePaths struct {
 	CA   string
 	GRPC TLSCertPath
 	HTTP TLSCertPath
 }
 
+// TLSCertPath is the path of a certificate and its key.
 type TLSCertPath struct {
 	Certificate string
 	Key         string
 }
 
+// TLSServerNames are the names the clients verify the certificates of the servers against.
 type TLSServerNames struct {
-	GRPC GRPCServerNames
-	HTTP HTTPServerNames
+	GRPC       GRPCServerNames
+	HTTP       HTTPServerNames
+	Memberlist string
 }
 
+// GRPCServerNames are the server names of the gRPC services.
 type GRPCServerNames struct {
-	Compactor     string
-	IndexGateway  string
-	Ingester      string
-	QueryFrontend string
-	Ruler         string
+	Compactor      string
+	IndexGateway   string
+	Ingester       string
+	QueryFrontend  string
+	QueryScheduler string
+	Ruler          string
 }
 
+// HTTPServerNames are the server names of the HTTP services.
 type HTTPServerNames struct {
 	Querier string
 }
@@ -0,0 +1,129 @@
+package internal
+
+import (
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
+
+	ssdlokiv1 "github.com/ssd-loki/loki-operator/api/v1"
+)
+
+// DefaultSsdLokiSpec returns the Loki settings used for every field left unset
+// in an SsdLoki spec. Service addresses depend on the stack name and namespace
+// and are therefore not part of it.
+//
+// A new value is returned on each call, so it can be merged into a spec without
+// sharing pointers between stacks.
+func DefaultSsdLokiSpec() ssdlokiv1.SsdLokiSpec {
+	return ssdlokiv1.SsdLokiSpec{
+		Template: &ssdlokiv1.SsdLokiTemplateSpec{
+			Read:    defaultComponentSpec(),
+			Write:   defaultComponentSpec(),
+			Backend: defaultComponentSpec(),
+		},
+		BloomBuild: &ssdlokiv1.BloomBuild{
+			Enabled: false,
+		},
+		BloomGateway: &ssdlokiv1.BloomGateway{
+			Enabled: false,
+		},
+		ChunkStoreConfig: &ssdlokiv1.ChunkStoreConfig{
+			ChunkCacheConfig: &ssdlokiv1.ChunkCacheConfig{
+				Background: &ssdlokiv1.CacheBackgroundConfig{
+					WritebackBuffer:     500000,
+					WritebackGoroutines: 1,
+					WritebackSizeLimit:  "500MB",
+				},
+				DefaultValidity: "0s",
+				Memcached: &ssdlokiv1.MemcachedConfig{
+					BatchSize:   4,
+					Parallelism: 5,
+				},
+				MemcachedClient: &ssdlokiv1.MemcachedClientConfig{
+					ConsistentHash: ptr.To(true),
+					MaxIdleConns:   72,
+					Timeout:        "2000ms",
+				},
+			},
+		},
+		Common: &ssdlokiv1.CommonConfig{
+			PathPrefix:        "/var/loki",
+			ReplicationFactor: 3,
+		},
+		IndexGateway: &ssdlokiv1.IndexGatewayConfig{
+			Mode: "simple",
+		},
+		Ingester: &ssdlokiv1.IngesterConfig{
+			ChunkEncoding: "snappy",
+		},
+		LimitsConfig: &ssdlokiv1.LimitsConfig{
+			MaxCacheFreshnessPerQuery: "10m",
+			QueryTimeout:              "300s",
+			RejectOldSamples:          ptr.To(true),
+			RejectOldSamplesMaxAge:    "168h",
+			SplitQueriesByInterval:    "15m",
+			VolumeEnabled:             ptr.To(true),
+		},
+		PatternIngester: &ssdlokiv1.PatternIngesterConfig{
+			Enabled: false,
+		},
+		QueryRange: &ssdlokiv1.QueryRangeConfig{
+			AlignQueriesWithStep: ptr.To(true),
+			CacheResults:         ptr.To(true),
+			ResultsCache: &ssdlokiv1.ResultsCacheConfig{
+				Cache: &ssdlokiv1.CacheConfig{
+					Background: &ssdlokiv1.CacheBackgroundConfig{
+						WritebackBuffer:     500000,
+						WritebackGoroutines: 1,
+						WritebackSizeLimit:  "500MB",
+					},
+					DefaultValidity: "12h",
+					MemcachedClient: &ssdlokiv1.MemcachedClientConfig{
+						ConsistentHash: ptr.To(true),
+						Timeout:        "500ms",
+						UpdateInterval: "1m",
+					},
+				},
+			},
+		},
+		RuntimeConfig: &ssdlokiv1.RuntimeConfig{
+			File: "/etc/loki/runtime-config/runtime-config.yaml",
+		},
+		SchemaConfig: &ssdlokiv1.SchemaConfig{
+			Configs: []ssdlokiv1.SchemaConfigEntry{
+				{
+					From: "2024-04-01",
+					Index: &ssdlokiv1.SchemaConfigIndex{
+						Period: "24h",
+						Prefix: "loki_index_",
+					},
+					Schema: "v13",
+					Store:  "tsdb",
+				},
+			},
+		},
+		Server: &ssdlokiv1.ServerConfig{
+			GRPCListenPort: 9095,
+			HTTPListenPort: 3100,
+		},
+		StorageConfig: &ssdlokiv1.StorageConfig{
+			BloomShipper: &ssdlokiv1.BloomShipperConfig{
+				WorkingDirectory: "/var/loki/data/bloomshipper",
+			},
+			Hedging: &ssdlokiv1.HedgingConfig{
+				At:           "250ms",
+				MaxPerSecond: 20,
+				UpTo:         3,
+			},
+		},
+		Tracing: &ssdlokiv1.TracingConfig{
+			Enabled: ptr.To(true),
+		},
+	}
+}
+
+func defaultComponentSpec() *ssdlokiv1.SsdLokiComponentSpec {
+	return &ssdlokiv1.SsdLokiComponentSpec{
+		Replicas:   ptr.To(int32(3)),
+		VolumeSize: ptr.To(resource.MustParse("10Gi")),
+	}
+}
@@ -0,0 +1,45 @@
+package gateway
+
+import (
+	"bytes"
+	"embed"
+	"text/template"
+
+	"github.com/ViaQ/logerr/kverrors"
+)
+
+const (
+	// TenantsFileName is the name of the tenants config file in the gateway secret
+	TenantsFileName = "tenants.yaml"
+	// RBACFileName is the name of the rbac config file in the gateway configmap
+	RBACFileName = "rbac.yaml"
+)
+
+var (
+	//go:embed gateway-tenants.yaml
+	gatewayTenantsYAMLTmplFile embed.FS
+
+	//go:embed gateway-rbac.yaml
+	gatewayRBACYAMLTmplFile embed.FS
+
+	gatewayTenantsYAMLTmpl = template.Must(template.ParseFS(gatewayTenantsYAMLTmplFile, "gateway-tenants.yaml"))
+
+	gatewayRBACYAMLTmpl = template.Must(template.ParseFS(gatewayRBACYAMLTmplFile, "gateway-rbac.yaml"))
+)
+
+// Build builds the tenants and rbac configuration files of the gateway
+func Build(opts Options) ([]byte, []byte, error) {
+	w := bytes.NewBuffer(nil)
+	if err := gatewayTenantsYAMLTmpl.Execute(w, opts); err != nil {
+		return nil, nil, kverrors.Wrap(err, "failed to create gateway tenants configuration")
+	}
+	tenants := w.Bytes()
+
+	w = bytes.NewBuffer(nil)
+	if err := gatewayRBACYAMLTmpl.Execute(w, opts); err != nil {
+		return nil, nil, kverrors.Wrap(err, "failed to create gateway rbac configuration")
+	}
+	rbac := w.Bytes()
+
+	return tenants, rbac, nil
+}
@@ -0,0 +1,35 @@
+package gateway
+
+import (
+	ssdlokiv1 "github.com/ssd-loki/loki-operator/api/v1"
+)
+
+// Options is used to render the gateway-tenants.yaml and gateway-rbac.yaml file templates.
+type Options struct {
+	Tenants       []Tenant
+	Authorization *ssdlokiv1.AuthorizationSpec
+}
+
+// Tenant is a tenant the gateway authenticates. Exactly one of OIDC and MTLS is set.
+type Tenant struct {
+	Name string
+	ID   string
+	OIDC *OIDC
+	MTLS *MTLS
+}
+
+// OIDC is the OpenID Connect configuration of a tenant including its client credentials.
+type OIDC struct {
+	ClientID      string
+	ClientSecret  string
+	IssuerURL     string
+	IssuerCAPath  string
+	RedirectURL   string
+	UsernameClaim string
+	GroupClaim    string
+}
+
+// MTLS is the client certificate configuration of a tenant.
+type MTLS struct {
+	CAPath string
+}
@@ -0,0 +1,68 @@
+package internal
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
+
+	ssdlokiv1 "github.com/ssd-loki/loki-operator/api/v1"
+)
+
+// StackSizeTable defines the spec presets for each size. They are merged into
+// an SsdLoki spec after the user values and before the static defaults, so any
+// value set in the spec wins over the preset.
+//
+// The table must not be modified; use DeepCopy before merging an entry.
+var StackSizeTable = map[ssdlokiv1.SsdLokiSizeType]ssdlokiv1.SsdLokiSpec{
+	ssdlokiv1.SizeDemo: {
+		Common: &ssdlokiv1.CommonConfig{
+			ReplicationFactor: 1,
+		},
+		Template: &ssdlokiv1.SsdLokiTemplateSpec{
+			Read:    componentSize(1, "", "", "10Gi"),
+			Write:   componentSize(1, "", "", "10Gi"),
+			Backend: componentSize(1, "", "", "10Gi"),
+		},
+	},
+	ssdlokiv1.SizeSmall: {
+		Template: &ssdlokiv1.SsdLokiTemplateSpec{
+			Read:    componentSize(2, "1", "2Gi", "10Gi"),
+			Write:   componentSize(3, "1", "4Gi", "10Gi"),
+			Backend: componentSize(3, "500m", "1Gi", "10Gi"),
+		},
+	},
+	ssdlokiv1.SizeMedium: {
+		Template: &ssdlokiv1.SsdLokiTemplateSpec{
+			Read:    componentSize(3, "2", "4Gi", "50Gi"),
+			Write:   componentSize(3, "2", "8Gi", "50Gi"),
+			Backend: componentSize(3, "1", "2Gi", "50Gi"),
+		},
+	},
+	ssdlokiv1.SizeLarge: {
+		Template: &ssdlokiv1.SsdLokiTemplateSpec{
+			Read:    componentSize(6, "4", "8Gi", "150Gi"),
+			Write:   componentSize(6, "4", "16Gi", "150Gi"),
+			Backend: componentSize(3, "2", "4Gi", "100Gi"),
+		},
+	},
+}
+
+// componentSize returns the preset of a single tier. Empty cpu and memory
+// leave the container without resource requests.
+func componentSize(replicas int32, cpu, memory, volume string) *ssdlokiv1.SsdLokiComponentSpec {
+	spec := &ssdlokiv1.SsdLokiComponentSpec{
+		Replicas:   ptr.To(replicas),
+		VolumeSize: ptr.To(resource.MustParse(volume)),
+	}
+
+	if cpu != "" && memory != "" {
+		spec.Resources = &corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse(cpu),
+				corev1.ResourceMemory: resource.MustParse(memory),
+			},
+		}
+	}
+
+	return spec
+}
@@ -0,0 +1,66 @@
+package internal
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	ssdlokiv1 "github.com/ssd-loki/loki-operator/api/v1"
+)
+
+func TestStackSizeTable_CoversAllSizes(t *testing.T) {
+	g := NewWithT(t)
+
+	for _, size := range []ssdlokiv1.SsdLokiSizeType{
+		ssdlokiv1.SizeDemo,
+		ssdlokiv1.SizeSmall,
+		ssdlokiv1.SizeMedium,
+		ssdlokiv1.SizeLarge,
+	} {
+		g.Expect(StackSizeTable).To(HaveKey(size))
+	}
+	g.Expect(StackSizeTable).To(HaveLen(4))
+}
+
+func TestStackSizeTable_WriteReplicasCoverReplicationFactor(t *testing.T) {
+	defaultRF := DefaultSsdLokiSpec().Common.ReplicationFactor
+
+	for size, spec := range StackSizeTable {
+		t.Run(string(size), func(t *testing.T) {
+			g := NewWithT(t)
+
+			rf := defaultRF
+			if spec.Common != nil && spec.Common.ReplicationFactor != 0 {
+				rf = spec.Common.ReplicationFactor
+			}
+
+			g.Expect(spec.Template).NotTo(BeNil())
+			for _, c := range []*ssdlokiv1.SsdLokiComponentSpec{spec.Template.Read, spec.Template.Write, spec.Template.Backend} {
+				g.Expect(c).NotTo(BeNil())
+				g.Expect(c.Replicas).NotTo(BeNil())
+				g.Expect(c.VolumeSize).NotTo(BeNil())
+			}
+			g.Expect(int(*spec.Template.Write.Replicas)).To(BeNumerically(">=", rf))
+		})
+	}
+}
+
+func TestStackSizeTable_GrowsWithSize(t *testing.T) {
+	g := NewWithT(t)
+
+	sizes := []ssdlokiv1.SsdLokiSizeType{ssdlokiv1.SizeSmall, ssdlokiv1.SizeMedium, ssdlokiv1.SizeLarge}
+	for i := 1; i < len(sizes); i++ {
+		prev := StackSizeTable[sizes[i-1]].Template
+		cur := StackSizeTable[sizes[i]].Template
+
+		for _, pair := range [][2]*ssdlokiv1.SsdLokiComponentSpec{
+			{prev.Read, cur.Read},
+			{prev.Write, cur.Write},
+			{prev.Backend, cur.Backend},
+		} {
+			g.Expect(pair[1].Resources.Requests.Cpu().Cmp(*pair[0].Resources.Requests.Cpu())).To(BeNumerically(">", 0))
+			g.Expect(pair[1].Resources.Requests.Memory().Cmp(*pair[0].Resources.Requests.Memory())).To(BeNumerically(">", 0))
+			g.Expect(pair[1].VolumeSize.Cmp(*pair[0].VolumeSize)).To(BeNumerically(">", 0))
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package manifests
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// NewLokiMemberListService returns the headless service used by all components to join the memberlist ring.
+func NewLokiMemberListService(opts Options) *corev1.Service {
+	serviceName := memberListName(opts.Name)
+
+	return &corev1.Service{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Service",
+			APIVersion: corev1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      serviceName,
+			Namespace: opts.Namespace,
+			Labels:    commonLabels(opts.Name, "memberlist"),
+		},
+		Spec: corev1.ServiceSpec{
+			Type:      corev1.ServiceTypeClusterIP,
+			ClusterIP: HeadLessClusterIP,
+			Ports: []corev1.ServicePort{
+				{
+					Name:       "tcp",
+					Port:       memberListPort,
+					Protocol:   protocolTCP,
+					TargetPort: intstr.FromString(lokiMemberListPortName),
+				},
+			},
+			Selector:                 memberListLabels(opts.Name),
+			PublishNotReadyAddresses: true,
+		},
+	}
+}
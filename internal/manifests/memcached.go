@@ -0,0 +1,321 @@
+package manifests
+
+import (
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ssdlokiv1 "github.com/ssd-loki/loki-operator/api/v1"
+)
+
+const (
+	memcachedPortName         = "memcached-client"
+	memcachedPort             = 11211
+	memcachedExporterPortName = "http-metrics"
+	memcachedExporterPort     = 9150
+
+	defaultMemcachedImage         = "docker.io/memcached:1.6.23-alpine"
+	defaultMemcachedExporterImage = "docker.io/prom/memcached-exporter:v0.14.2"
+
+	defaultChunksCacheMemoryMB  = 8192
+	defaultResultsCacheMemoryMB = 1024
+	defaultCacheMaxItemSize     = "5m"
+	defaultCacheConnectionLimit = 16384
+
+	// labelCache distinguishes the pods of the chunks and results caches, which
+	// share the memcached component label.
+	labelCache = "ssd-loki.ssd-loki.com/cache"
+)
+
+// ChunksCacheName is the name of the managed memcached StatefulSet and Service of the chunks cache.
+func ChunksCacheName(stackName string) string {
+	return fmt.Sprintf("%s-chunks-cache", stackName)
+}
+
+// ResultsCacheName is the name of the managed memcached StatefulSet and Service of the results cache.
+func ResultsCacheName(stackName string) string {
+	return fmt.Sprintf("%s-results-cache", stackName)
+}
+
+// managedCache describes one of the caches memcached can be deployed for.
+type managedCache struct {
+	name            string
+	spec            *ssdlokiv1.ManagedCacheSpec
+	defaultMemoryMB int32
+}
+
+// managedCaches returns the caches of the stack running in managed mode.
+func managedCaches(opts Options) []managedCache {
+	var caches []managedCache
+
+	if cc := chunkCacheConfig(opts.Stack.Spec); cc != nil && cc.Mode == ssdlokiv1.CacheModeManaged {
+		caches = append(caches, managedCache{
+			name:            ChunksCacheName(opts.Name),
+			spec:            cc.Managed,
+			defaultMemoryMB: defaultChunksCacheMemoryMB,
+		})
+	}
+	if rc := resultsCacheConfig(opts.Stack.Spec); rc != nil && rc.Mode == ssdlokiv1.CacheModeManaged {
+		caches = append(caches, managedCache{
+			name:            ResultsCacheName(opts.Name),
+			spec:            rc.Managed,
+			defaultMemoryMB: defaultResultsCacheMemoryMB,
+		})
+	}
+
+	return caches
+}
+
+func chunkCacheConfig(spec ssdlokiv1.SsdLokiSpec) *ssdlokiv1.ChunkCacheConfig {
+	if spec.ChunkStoreConfig == nil {
+		return nil
+	}
+	return spec.ChunkStoreConfig.ChunkCacheConfig
+}
+
+func resultsCacheConfig(spec ssdlokiv1.SsdLokiSpec) *ssdlokiv1.CacheConfig {
+	if spec.QueryRange == nil || spec.QueryRange.ResultsCache == nil {
+		return nil
+	}
+	return spec.QueryRange.ResultsCache.Cache
+}
+
+// applyCacheAddresses points the memcached clients of the caches in managed mode
+// at the services deployed for them.
+func applyCacheAddresses(opts Options, spec *ssdlokiv1.SsdLokiSpec) {
+	if cc := chunkCacheConfig(*spec); cc != nil && cc.Mode == ssdlokiv1.CacheModeManaged {
+		if cc.MemcachedClient == nil {
+			cc.MemcachedClient = &ssdlokiv1.MemcachedClientConfig{}
+		}
+		cc.MemcachedClient.Addresses = memcachedAddress(ChunksCacheName(opts.Name), opts.Namespace)
+	}
+	if rc := resultsCacheConfig(*spec); rc != nil && rc.Mode == ssdlokiv1.CacheModeManaged {
+		if rc.MemcachedClient == nil {
+			rc.MemcachedClient = &ssdlokiv1.MemcachedClientConfig{}
+		}
+		rc.MemcachedClient.Addresses = memcachedAddress(ResultsCacheName(opts.Name), opts.Namespace)
+	}
+}
+
+// memcachedAddress returns the address Loki discovers the memcached pods behind
+// a headless service with.
+func memcachedAddress(serviceName, namespace string) string {
+	return fmt.Sprintf("dnssrvnoa+_%s._tcp.%s", memcachedPortName, fqdn(serviceName, namespace))
+}
+
+// BuildMemcached builds the memcached StatefulSets and headless Services of the
+// caches in managed mode.
+func BuildMemcached(opts Options) []client.Object {
+	var objs []client.Object
+	for _, c := range managedCaches(opts) {
+		objs = append(objs,
+			newMemcachedStatefulSet(opts, c),
+			newMemcachedService(opts, c),
+		)
+	}
+	return objs
+}
+
+// obsoleteMemcachedObjects returns the memcached objects of the caches that are
+// not in managed mode.
+func obsoleteMemcachedObjects(opts Options) []client.Object {
+	managed := map[string]bool{}
+	for _, c := range managedCaches(opts) {
+		managed[c.name] = true
+	}
+
+	var objs []client.Object
+	for _, name := range []string{ChunksCacheName(opts.Name), ResultsCacheName(opts.Name)} {
+		if managed[name] {
+			continue
+		}
+		meta := metav1.ObjectMeta{Name: name, Namespace: opts.Namespace}
+		objs = append(objs,
+			&appsv1.StatefulSet{ObjectMeta: meta},
+			&corev1.Service{ObjectMeta: meta},
+		)
+	}
+	return objs
+}
+
+func memcachedLabels(opts Options, c managedCache) labels.Set {
+	return labels.Merge(commonLabels(opts.Name, ComponentMemcached), map[string]string{
+		labelCache: c.name,
+	})
+}
+
+func newMemcachedStatefulSet(opts Options, c managedCache) *appsv1.StatefulSet {
+	l := memcachedLabels(opts, c)
+
+	spec := c.spec
+	if spec == nil {
+		spec = &ssdlokiv1.ManagedCacheSpec{}
+	}
+
+	replicas := int32(1)
+	if spec.Replicas != nil {
+		replicas = *spec.Replicas
+	}
+	memoryMB := spec.MemoryLimitMB
+	if memoryMB == 0 {
+		memoryMB = c.defaultMemoryMB
+	}
+	maxItemSize := spec.MaxItemSize
+	if maxItemSize == "" {
+		maxItemSize = defaultCacheMaxItemSize
+	}
+	connections := spec.ConnectionLimit
+	if connections == 0 {
+		connections = defaultCacheConnectionLimit
+	}
+
+	securityContext := &corev1.SecurityContext{
+		AllowPrivilegeEscalation: ptr.To(false),
+		Capabilities: &corev1.Capabilities{
+			Drop: []corev1.Capability{"ALL"},
+		},
+		ReadOnlyRootFilesystem: ptr.To(true),
+	}
+
+	memcached := corev1.Container{
+		Name:            "memcached",
+		Image:           opts.MemcachedImage,
+		ImagePullPolicy: corev1.PullIfNotPresent,
+		Args: []string{
+			fmt.Sprintf("-m %d", memoryMB),
+			"--extended=modern,track_sizes",
+			fmt.Sprintf("-I %s", maxItemSize),
+			fmt.Sprintf("-c %d", connections),
+			"-v",
+			fmt.Sprintf("-p %d", memcachedPort),
+		},
+		Ports: []corev1.ContainerPort{
+			{
+				Name:          memcachedPortName,
+				ContainerPort: memcachedPort,
+				Protocol:      protocolTCP,
+			},
+		},
+		Resources:       memcachedResources(spec, memoryMB),
+		SecurityContext: securityContext,
+	}
+
+	exporter := corev1.Container{
+		Name:            "exporter",
+		Image:           opts.MemcachedExporterImage,
+		ImagePullPolicy: corev1.PullIfNotPresent,
+		Args: []string{
+			fmt.Sprintf("--memcached.address=localhost:%d", memcachedPort),
+			fmt.Sprintf("--web.listen-address=0.0.0.0:%d", memcachedExporterPort),
+		},
+		Ports: []corev1.ContainerPort{
+			{
+				Name:          memcachedExporterPortName,
+				ContainerPort: memcachedExporterPort,
+				Protocol:      protocolTCP,
+			},
+		},
+		SecurityContext: securityContext,
+	}
+
+	return &appsv1.StatefulSet{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "StatefulSet",
+			APIVersion: appsv1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      c.name,
+			Namespace: opts.Namespace,
+			Labels:    l,
+		},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas: ptr.To(replicas),
+			Selector: &metav1.LabelSelector{
+				MatchLabels: l,
+			},
+			ServiceName: c.name,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: l,
+				},
+				Spec: corev1.PodSpec{
+					ServiceAccountName:            serviceAccountName(opts.Name),
+					TerminationGracePeriodSeconds: ptr.To(int64(60)),
+					SecurityContext: &corev1.PodSecurityContext{
+						FSGroup:      ptr.To(int64(11211)),
+						RunAsGroup:   ptr.To(int64(11211)),
+						RunAsNonRoot: ptr.To(true),
+						RunAsUser:    ptr.To(int64(11211)),
+					},
+					Containers: []corev1.Container{memcached, exporter},
+				},
+			},
+			UpdateStrategy: appsv1.StatefulSetUpdateStrategy{
+				Type: appsv1.RollingUpdateStatefulSetStrategyType,
+			},
+			PodManagementPolicy:  appsv1.ParallelPodManagement,
+			RevisionHistoryLimit: ptr.To(int32(10)),
+		},
+	}
+}
+
+// memcachedResources returns the resources of the memcached container, which
+// default to the item memory plus 20% overhead for connections and bookkeeping.
+func memcachedResources(spec *ssdlokiv1.ManagedCacheSpec, memoryMB int32) corev1.ResourceRequirements {
+	if spec.Resources != nil {
+		return *spec.Resources.DeepCopy()
+	}
+
+	memory := resource.MustParse(fmt.Sprintf("%dMi", memoryMB*6/5))
+	return corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("500m"),
+			corev1.ResourceMemory: memory,
+		},
+		Limits: corev1.ResourceList{
+			corev1.ResourceMemory: memory,
+		},
+	}
+}
+
+func newMemcachedService(opts Options, c managedCache) *corev1.Service {
+	l := memcachedLabels(opts, c)
+
+	return &corev1.Service{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Service",
+			APIVersion: corev1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      c.name,
+			Namespace: opts.Namespace,
+			Labels:    l,
+		},
+		Spec: corev1.ServiceSpec{
+			Type:      corev1.ServiceTypeClusterIP,
+			ClusterIP: HeadLessClusterIP,
+			Ports: []corev1.ServicePort{
+				{
+					Name:       memcachedPortName,
+					Port:       memcachedPort,
+					Protocol:   protocolTCP,
+					TargetPort: intstr.FromString(memcachedPortName),
+				},
+				{
+					Name:       memcachedExporterPortName,
+					Port:       memcachedExporterPort,
+					Protocol:   protocolTCP,
+					TargetPort: intstr.FromString(memcachedExporterPortName),
+				},
+			},
+			Selector: l,
+		},
+	}
+}
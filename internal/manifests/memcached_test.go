@@ -0,0 +1,106 @@
+package manifests
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/utils/ptr"
+
+	ssdlokiv1 "github.com/ssd-loki/loki-operator/api/v1"
+	"github.com/ssd-loki/loki-operator/internal/manifests/internal/config"
+)
+
+func cacheSpec(chunks, results ssdlokiv1.CacheMode) func(*ssdlokiv1.SsdLokiSpec) {
+	return func(spec *ssdlokiv1.SsdLokiSpec) {
+		spec.ChunkStoreConfig = &ssdlokiv1.ChunkStoreConfig{
+			ChunkCacheConfig: &ssdlokiv1.ChunkCacheConfig{
+				Mode: chunks,
+				Managed: &ssdlokiv1.ManagedCacheSpec{
+					Replicas:      ptr.To(int32(2)),
+					MemoryLimitMB: 2048,
+				},
+			},
+		}
+		spec.QueryRange = &ssdlokiv1.QueryRangeConfig{
+			ResultsCache: &ssdlokiv1.ResultsCacheConfig{
+				Cache: &ssdlokiv1.CacheConfig{Mode: results},
+			},
+		}
+		if results == ssdlokiv1.CacheModeExternal {
+			spec.QueryRange.ResultsCache.Cache.MemcachedClient = &ssdlokiv1.MemcachedClientConfig{
+				Addresses: "dns+memcached.cache.svc:11211",
+			}
+		}
+	}
+}
+
+func TestBuildAll_ManagedCaches(t *testing.T) {
+	g := NewWithT(t)
+
+	objs, err := BuildAll(newOptions(t, cacheSpec(ssdlokiv1.CacheModeManaged, ssdlokiv1.CacheModeManaged)))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	sts := map[string]*appsv1.StatefulSet{}
+	svcs := map[string]*corev1.Service{}
+	for _, obj := range objs {
+		switch o := obj.(type) {
+		case *appsv1.StatefulSet:
+			sts[o.Name] = o
+		case *corev1.Service:
+			svcs[o.Name] = o
+		}
+	}
+
+	chunks := sts["loki-chunks-cache"]
+	g.Expect(chunks).NotTo(BeNil())
+	g.Expect(*chunks.Spec.Replicas).To(Equal(int32(2)))
+	g.Expect(chunks.Spec.Template.Spec.Containers).To(HaveLen(2))
+	g.Expect(chunks.Spec.Template.Spec.Containers[0].Args).To(ContainElement("-m 2048"))
+	g.Expect(chunks.Spec.Template.Spec.Containers[0].Resources.Limits.Memory().Equal(resource.MustParse("2457Mi"))).To(BeTrue())
+
+	results := sts["loki-results-cache"]
+	g.Expect(results).NotTo(BeNil())
+	g.Expect(*results.Spec.Replicas).To(Equal(int32(1)))
+	g.Expect(results.Spec.Template.Spec.Containers[0].Args).To(ContainElement("-m 1024"))
+
+	for _, name := range []string{"loki-chunks-cache", "loki-results-cache"} {
+		svc := svcs[name]
+		g.Expect(svc).NotTo(BeNil(), name)
+		g.Expect(svc.Spec.ClusterIP).To(Equal(HeadLessClusterIP))
+		g.Expect(labels.SelectorFromSet(svc.Spec.Selector).Matches(labels.Set(sts[name].Spec.Template.Labels))).To(BeTrue())
+	}
+	g.Expect(labels.SelectorFromSet(svcs["loki-chunks-cache"].Spec.Selector).Matches(labels.Set(results.Spec.Template.Labels))).To(BeFalse())
+}
+
+func TestLokiConfigMap_CacheAddresses(t *testing.T) {
+	g := NewWithT(t)
+
+	cm, err := LokiConfigMap(newOptions(t, cacheSpec(ssdlokiv1.CacheModeManaged, ssdlokiv1.CacheModeExternal)))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	cfg := cm.Data[config.LokiConfigFileName]
+	g.Expect(cfg).To(ContainSubstring("addresses: dnssrvnoa+_memcached-client._tcp.loki-chunks-cache.ns.svc.cluster.local"))
+	g.Expect(cfg).To(ContainSubstring("addresses: dns+memcached.cache.svc:11211"))
+}
+
+func TestObsoleteObjects_DisabledCaches(t *testing.T) {
+	g := NewWithT(t)
+
+	objs := obsoleteMemcachedObjects(newOptions(t, cacheSpec(ssdlokiv1.CacheModeManaged, "")))
+
+	var names []string
+	for _, obj := range objs {
+		names = append(names, obj.GetName())
+	}
+	g.Expect(names).To(ConsistOf("loki-results-cache", "loki-results-cache"))
+
+	objs, err := BuildAll(newOptions(t, cacheSpec("", "")))
+	g.Expect(err).NotTo(HaveOccurred())
+	for _, obj := range objs {
+		g.Expect(obj.GetName()).NotTo(HaveSuffix("-cache"))
+	}
+}
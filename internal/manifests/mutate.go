@@ -0,0 +1,172 @@
+package manifests
+
+import (
+	"reflect"
+
+	"github.com/ViaQ/logerr/kverrors"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// MutateFuncFor returns a mutate function based on the existing resource's concrete type.
+// It currently supports the following types and will return an error for other types:
+//
+//   - ConfigMap
+//   - Secret
+//   - Service
+//   - ServiceAccount
+//   - Deployment
+//   - StatefulSet
+//   - PodDisruptionBudget
+func MutateFuncFor(existing, desired client.Object) controllerutil.MutateFn {
+	return func() error {
+		existingAnnotations := existing.GetAnnotations()
+		if err := mergeMap(&existingAnnotations, desired.GetAnnotations()); err != nil {
+			return err
+		}
+		existing.SetAnnotations(existingAnnotations)
+
+		existingLabels := existing.GetLabels()
+		if err := mergeMap(&existingLabels, desired.GetLabels()); err != nil {
+			return err
+		}
+		existing.SetLabels(existingLabels)
+
+		switch existing.(type) {
+		case *corev1.ConfigMap:
+			cm := existing.(*corev1.ConfigMap)
+			wantCm := desired.(*corev1.ConfigMap)
+			mutateConfigMap(cm, wantCm)
+
+		case *corev1.Secret:
+			s := existing.(*corev1.Secret)
+			wantS := desired.(*corev1.Secret)
+			mutateSecret(s, wantS)
+
+		case *corev1.Service:
+			svc := existing.(*corev1.Service)
+			wantSvc := desired.(*corev1.Service)
+			mutateService(svc, wantSvc)
+
+		case *corev1.ServiceAccount:
+			sa := existing.(*corev1.ServiceAccount)
+			wantSa := desired.(*corev1.ServiceAccount)
+			mutateServiceAccount(sa, wantSa)
+
+		case *appsv1.Deployment:
+			dpl := existing.(*appsv1.Deployment)
+			wantDpl := desired.(*appsv1.Deployment)
+			mutateDeployment(dpl, wantDpl)
+
+		case *appsv1.StatefulSet:
+			sts := existing.(*appsv1.StatefulSet)
+			wantSts := desired.(*appsv1.StatefulSet)
+			mutateStatefulSet(sts, wantSts)
+
+		case *policyv1.PodDisruptionBudget:
+			pdb := existing.(*policyv1.PodDisruptionBudget)
+			wantPdb := desired.(*policyv1.PodDisruptionBudget)
+			mutatePodDisruptionBudget(pdb, wantPdb)
+
+		default:
+			t := reflect.TypeOf(existing).String()
+			return kverrors.New("missing mutate implementation for resource type", "type", t)
+		}
+		return nil
+	}
+}
+
+func mergeMap(existing *map[string]string, desired map[string]string) error {
+	if *existing == nil {
+		*existing = map[string]string{}
+	}
+	for k, v := range desired {
+		(*existing)[k] = v
+	}
+	return nil
+}
+
+func mutateConfigMap(existing, desired *corev1.ConfigMap) {
+	existing.BinaryData = desired.BinaryData
+	existing.Data = desired.Data
+}
+
+func mutateSecret(existing, desired *corev1.Secret) {
+	existing.Annotations = desired.Annotations
+	existing.Labels = desired.Labels
+	existing.Data = desired.Data
+}
+
+func mutateServiceAccount(existing, desired *corev1.ServiceAccount) {
+	existing.Labels = desired.Labels
+	existing.Annotations = desired.Annotations
+	existing.AutomountServiceAccountToken = desired.AutomountServiceAccountToken
+}
+
+func mutateService(existing, desired *corev1.Service) {
+	// ClusterIP and ClusterIPs are immutable and set by the API server on creation,
+	// so we keep the existing values for them.
+	existing.Spec.Ports = desired.Spec.Ports
+	existing.Spec.Selector = desired.Spec.Selector
+	existing.Spec.PublishNotReadyAddresses = desired.Spec.PublishNotReadyAddresses
+}
+
+func mutateDeployment(existing, desired *appsv1.Deployment) {
+	// Deployment selector is immutable so we set it only on creation.
+	if existing.CreationTimestamp.IsZero() {
+		existing.Spec.Selector = desired.Spec.Selector
+	}
+	existing.Spec.Replicas = desired.Spec.Replicas
+	existing.Spec.Strategy = desired.Spec.Strategy
+	existing.Spec.RevisionHistoryLimit = desired.Spec.RevisionHistoryLimit
+	existing.Spec.Template = desired.Spec.Template
+}
+
+func mutateStatefulSet(existing, desired *appsv1.StatefulSet) {
+	// StatefulSet selector, volume claim templates, pod management policy and
+	// service name are immutable so we set them only on creation.
+	if existing.CreationTimestamp.IsZero() {
+		existing.Spec.Selector = desired.Spec.Selector
+		existing.Spec.VolumeClaimTemplates = desired.Spec.VolumeClaimTemplates
+		existing.Spec.PodManagementPolicy = desired.Spec.PodManagementPolicy
+		existing.Spec.ServiceName = desired.Spec.ServiceName
+	}
+	existing.Spec.Replicas = desired.Spec.Replicas
+	existing.Spec.UpdateStrategy = desired.Spec.UpdateStrategy
+	existing.Spec.RevisionHistoryLimit = desired.Spec.RevisionHistoryLimit
+	existing.Spec.Template = desired.Spec.Template
+}
+
+// VolumeClaimTemplatesChanged reports whether the volume size or storage class
+// of the desired StatefulSet differs from the existing one. Volume claim
+// templates are immutable, so MutateFuncFor does not apply such changes.
+func VolumeClaimTemplatesChanged(existing, desired *appsv1.StatefulSet) bool {
+	current := make(map[string]corev1.PersistentVolumeClaimSpec, len(existing.Spec.VolumeClaimTemplates))
+	for _, pvc := range existing.Spec.VolumeClaimTemplates {
+		current[pvc.Name] = pvc.Spec
+	}
+
+	for _, pvc := range desired.Spec.VolumeClaimTemplates {
+		spec, ok := current[pvc.Name]
+		if !ok {
+			return true
+		}
+		// An unset storage class is defaulted by the API server.
+		if sc := pvc.Spec.StorageClassName; sc != nil && (spec.StorageClassName == nil || *spec.StorageClassName != *sc) {
+			return true
+		}
+		if pvc.Spec.Resources.Requests.Storage().Cmp(*spec.Resources.Requests.Storage()) != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func mutatePodDisruptionBudget(existing, desired *policyv1.PodDisruptionBudget) {
+	existing.Labels = desired.Labels
+	existing.Annotations = desired.Annotations
+	existing.Spec = desired.Spec
+}
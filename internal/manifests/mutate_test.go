@@ -0,0 +1,93 @@
+package manifests
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	ssdlokiv1 "github.com/ssd-loki/loki-operator/api/v1"
+)
+
+func TestMutateFuncFor_StatefulSetKeepsImmutableFields(t *testing.T) {
+	g := NewWithT(t)
+
+	existing := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "loki-write", CreationTimestamp: metav1.Now()},
+		Spec: appsv1.StatefulSetSpec{
+			PodManagementPolicy: appsv1.OrderedReadyPodManagement,
+			ServiceName:         "loki-write-headless",
+			Replicas:            ptr.To(int32(3)),
+		},
+	}
+	desired := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "loki-write"},
+		Spec: appsv1.StatefulSetSpec{
+			PodManagementPolicy: appsv1.ParallelPodManagement,
+			ServiceName:         "loki-write-other",
+			Replicas:            ptr.To(int32(5)),
+		},
+	}
+
+	g.Expect(MutateFuncFor(existing, desired)()).To(Succeed())
+	g.Expect(existing.Spec.PodManagementPolicy).To(Equal(appsv1.OrderedReadyPodManagement))
+	g.Expect(existing.Spec.ServiceName).To(Equal("loki-write-headless"))
+	g.Expect(*existing.Spec.Replicas).To(Equal(int32(5)))
+}
+
+func TestVolumeClaimTemplatesChanged(t *testing.T) {
+	existing := statefulSets(t, newOptions(t, nil))["loki-write"]
+
+	tt := []struct {
+		desc string
+		tpl  *ssdlokiv1.SsdLokiComponentSpec
+		want bool
+	}{
+		{
+			desc: "unchanged",
+			want: false,
+		},
+		{
+			desc: "replicas changed",
+			tpl:  &ssdlokiv1.SsdLokiComponentSpec{Replicas: ptr.To(int32(5))},
+			want: false,
+		},
+		{
+			desc: "volume size changed",
+			tpl:  &ssdlokiv1.SsdLokiComponentSpec{VolumeSize: ptr.To(resource.MustParse("50Gi"))},
+			want: true,
+		},
+		{
+			desc: "storage class changed",
+			tpl:  &ssdlokiv1.SsdLokiComponentSpec{StorageClassName: "fast"},
+			want: true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.desc, func(t *testing.T) {
+			g := NewWithT(t)
+
+			desired := statefulSets(t, newOptions(t, func(spec *ssdlokiv1.SsdLokiSpec) {
+				spec.Template = &ssdlokiv1.SsdLokiTemplateSpec{Write: tc.tpl}
+			}))["loki-write"]
+
+			g.Expect(VolumeClaimTemplatesChanged(existing, desired)).To(Equal(tc.want))
+		})
+	}
+}
+
+func TestVolumeClaimTemplatesChanged_DefaultedStorageClass(t *testing.T) {
+	g := NewWithT(t)
+
+	desired := statefulSets(t, newOptions(t, nil))["loki-write"]
+	existing := desired.DeepCopy()
+	for i := range existing.Spec.VolumeClaimTemplates {
+		existing.Spec.VolumeClaimTemplates[i].Spec.StorageClassName = ptr.To("standard")
+	}
+
+	g.Expect(VolumeClaimTemplatesChanged(existing, desired)).To(BeFalse())
+}
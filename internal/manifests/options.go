@@ -4,8 +4,11 @@ import (
 	"strings"
 	"time"
 
+	"github.com/prometheus/common/model"
+
 	ssdlokiv1 "github.com/ssd-loki/loki-operator/api/v1"
 	"github.com/ssd-loki/loki-operator/internal/manifests/internal/config"
+	"github.com/ssd-loki/loki-operator/internal/manifests/storage"
 )
 
 // Options is a set of configuration values to use when building manifests such as resource sizes, etc.
@@ -16,14 +19,37 @@ type Options struct {
 	Image        string
 	GatewayImage string
 
-	Gates                configv1.FeatureGates
-	ssdloki              ssdlokiv1.SsdLoki
-	ResourceRequirements ComponentResources
+	MemcachedImage         string
+	MemcachedExporterImage string
 
-	RulesConfigMapNames []string
+	Stack                ssdlokiv1.SsdLoki
+	ResourceRequirements ComponentResources
 
 	ObjectStorage storage.Options
 
+	// ConfigSHA1 is the hash of the rendered Loki config and the Secrets it
+	// references. It is set by BuildAll.
+	ConfigSHA1 string
+
+	// CertificatesSHA1 is the hash of the CA bundle and the certificates of
+	// internal TLS, if enabled.
+	CertificatesSHA1 string
+
+	// AlertManagerSHA1 is the hash of the Secrets and CA bundles of the
+	// Alertmanager clients of the ruler.
+	AlertManagerSHA1 string
+
+	// RulerSecret holds the credentials of the ruler remote write, if any.
+	RulerSecret *RulerSecret
+
+	// AlertingRules and RecordingRules are the rules selected by the stack.
+	AlertingRules  []ssdlokiv1.AlertingRule
+	RecordingRules []ssdlokiv1.RecordingRule
+
+	// RulesConfigMapNames are the names of the ConfigMaps holding the rule files
+	// of each tenant, keyed by tenant ID. It is set by BuildAll.
+	RulesConfigMapNames map[string][]string
+
 	Timeouts TimeoutConfig
 
 	Tenants Tenants
@@ -114,41 +140,30 @@ func (o Options) TLSCipherSuites() string {
 	return strings.Join(o.TLSProfile.Ciphers, ",")
 }
 
-// NewTimeoutConfig creates a TimeoutConfig from the QueryTimeout values in the spec's limits.
-func NewTimeoutConfig(s *lokiv1.LimitsSpec) (TimeoutConfig, error) {
-	if s == nil {
-		return defaultTimeoutConfig, nil
-	}
-
-	if s.Global == nil && s.Tenants == nil {
-		return defaultTimeoutConfig, nil
-	}
-
+// NewTimeoutConfig creates a TimeoutConfig from the largest QueryTimeout of the
+// global limits and the per-tenant overrides. The Loki default query timeout is
+// used as a lower bound.
+func NewTimeoutConfig(limits *ssdlokiv1.LimitsConfig, overrides map[string]ssdlokiv1.PerTenantLimitsConfig) (TimeoutConfig, error) {
 	queryTimeout := lokiDefaultQueryTimeout
-	if s.Global != nil && s.Global.QueryLimits != nil && s.Global.QueryLimits.QueryTimeout != "" {
-		var err error
-		globalQueryTimeout, err := time.ParseDuration(s.Global.QueryLimits.QueryTimeout)
-		if err != nil {
-			return TimeoutConfig{}, err
-		}
 
-		if globalQueryTimeout > queryTimeout {
-			queryTimeout = globalQueryTimeout
-		}
+	timeouts := make([]string, 0, len(overrides)+1)
+	if limits != nil {
+		timeouts = append(timeouts, limits.QueryTimeout)
+	}
+	for _, o := range overrides {
+		timeouts = append(timeouts, o.QueryTimeout)
 	}
 
-	for _, tLimit := range s.Tenants {
-		if tLimit.QueryLimits == nil || tLimit.QueryLimits.QueryTimeout == "" {
+	for _, t := range timeouts {
+		if t == "" {
 			continue
 		}
-
-		tenantQueryTimeout, err := time.ParseDuration(tLimit.QueryLimits.QueryTimeout)
+		d, err := model.ParseDuration(t)
 		if err != nil {
 			return TimeoutConfig{}, err
 		}
-
-		if tenantQueryTimeout > queryTimeout {
-			queryTimeout = tenantQueryTimeout
+		if time.Duration(d) > queryTimeout {
+			queryTimeout = time.Duration(d)
 		}
 	}
 
@@ -177,3 +192,24 @@ func calculateHTTPTimeouts(queryTimeout time.Duration) TimeoutConfig {
 		},
 	}
 }
+
+// lokiHTTPPort returns the HTTP port all Loki components listen on.
+func (o Options) lokiHTTPPort() int32 {
+	return LokiHTTPPort(&o.Stack)
+}
+
+// LokiHTTPPort returns the HTTP port all Loki components of a stack listen on.
+func LokiHTTPPort(stack *ssdlokiv1.SsdLoki) int32 {
+	if stack.Spec.Server != nil && stack.Spec.Server.HTTPListenPort != 0 {
+		return int32(stack.Spec.Server.HTTPListenPort)
+	}
+	return httpPort
+}
+
+// lokiGRPCPort returns the gRPC port all Loki components listen on.
+func (o Options) lokiGRPCPort() int32 {
+	if o.Stack.Spec.Server != nil && o.Stack.Spec.Server.GRPCListenPort != 0 {
+		return int32(o.Stack.Spec.Server.GRPCListenPort)
+	}
+	return grpcPort
+}
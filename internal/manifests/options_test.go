@@ -0,0 +1,63 @@
+package manifests
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	ssdlokiv1 "github.com/ssd-loki/loki-operator/api/v1"
+)
+
+func TestNewTimeoutConfig(t *testing.T) {
+	tt := []struct {
+		desc      string
+		limits    *ssdlokiv1.LimitsConfig
+		overrides map[string]ssdlokiv1.PerTenantLimitsConfig
+		wantQuery time.Duration
+	}{
+		{
+			desc:      "no limits",
+			wantQuery: lokiDefaultQueryTimeout,
+		},
+		{
+			desc:      "global below the default",
+			limits:    &ssdlokiv1.LimitsConfig{QueryTimeout: "30s"},
+			wantQuery: lokiDefaultQueryTimeout,
+		},
+		{
+			desc:      "global",
+			limits:    &ssdlokiv1.LimitsConfig{QueryTimeout: "10m"},
+			wantQuery: 10 * time.Minute,
+		},
+		{
+			desc:   "largest tenant override",
+			limits: &ssdlokiv1.LimitsConfig{QueryTimeout: "10m"},
+			overrides: map[string]ssdlokiv1.PerTenantLimitsConfig{
+				"team-a": {QueryTimeout: "1h"},
+				"team-b": {QueryTimeout: "20m"},
+				"team-c": {},
+			},
+			wantQuery: time.Hour,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.desc, func(t *testing.T) {
+			g := NewWithT(t)
+
+			got, err := NewTimeoutConfig(tc.limits, tc.overrides)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(got).To(Equal(calculateHTTPTimeouts(tc.wantQuery)))
+		})
+	}
+}
+
+func TestNewTimeoutConfig_InvalidDuration(t *testing.T) {
+	g := NewWithT(t)
+
+	_, err := NewTimeoutConfig(nil, map[string]ssdlokiv1.PerTenantLimitsConfig{
+		"team-a": {QueryTimeout: "soon"},
+	})
+	g.Expect(err).To(HaveOccurred())
+}
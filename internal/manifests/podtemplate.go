@@ -0,0 +1,84 @@
+package manifests
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	ssdlokiv1 "github.com/ssd-loki/loki-operator/api/v1"
+)
+
+// configurePodTemplate merges the pod template overrides of a tier into its
+// generated StatefulSet:
+//
+//   - Labels and annotations are added, keeping the values set by the operator
+//     on conflicts, as selectors and config hashes depend on them.
+//   - The node selector entries are added.
+//   - Tolerations are appended.
+//   - The node, pod and pod anti-affinity each replace the generated one if set.
+//   - The priority class name is set.
+func configurePodTemplate(sts *appsv1.StatefulSet, spec *ssdlokiv1.SsdLokiComponentSpec) {
+	if spec == nil || spec.PodTemplate == nil {
+		return
+	}
+
+	pt := spec.PodTemplate
+	tpl := &sts.Spec.Template
+
+	tpl.Labels = mergeMissing(tpl.Labels, pt.Labels)
+	tpl.Annotations = mergeMissing(tpl.Annotations, pt.Annotations)
+
+	if len(pt.NodeSelector) > 0 {
+		if tpl.Spec.NodeSelector == nil {
+			tpl.Spec.NodeSelector = map[string]string{}
+		}
+		for k, v := range pt.NodeSelector {
+			tpl.Spec.NodeSelector[k] = v
+		}
+	}
+
+	for _, t := range pt.Tolerations {
+		tpl.Spec.Tolerations = append(tpl.Spec.Tolerations, *t.DeepCopy())
+	}
+
+	if pt.Affinity != nil {
+		tpl.Spec.Affinity = mergeAffinity(tpl.Spec.Affinity, pt.Affinity)
+	}
+
+	if pt.PriorityClassName != "" {
+		tpl.Spec.PriorityClassName = pt.PriorityClassName
+	}
+}
+
+// mergeMissing adds the entries of src missing in dst.
+func mergeMissing(dst, src map[string]string) map[string]string {
+	if len(src) == 0 {
+		return dst
+	}
+	if dst == nil {
+		dst = map[string]string{}
+	}
+	for k, v := range src {
+		if _, ok := dst[k]; !ok {
+			dst[k] = v
+		}
+	}
+	return dst
+}
+
+func mergeAffinity(generated, override *corev1.Affinity) *corev1.Affinity {
+	merged := &corev1.Affinity{}
+	if generated != nil {
+		merged = generated.DeepCopy()
+	}
+
+	if override.NodeAffinity != nil {
+		merged.NodeAffinity = override.NodeAffinity.DeepCopy()
+	}
+	if override.PodAffinity != nil {
+		merged.PodAffinity = override.PodAffinity.DeepCopy()
+	}
+	if override.PodAntiAffinity != nil {
+		merged.PodAntiAffinity = override.PodAntiAffinity.DeepCopy()
+	}
+	return merged
+}
@@ -0,0 +1,94 @@
+package manifests
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+
+	ssdlokiv1 "github.com/ssd-loki/loki-operator/api/v1"
+)
+
+func podTemplateSpec(pt *ssdlokiv1.PodTemplateSpec) func(*ssdlokiv1.SsdLokiSpec) {
+	return func(spec *ssdlokiv1.SsdLokiSpec) {
+		spec.Template = &ssdlokiv1.SsdLokiTemplateSpec{
+			Write: &ssdlokiv1.SsdLokiComponentSpec{PodTemplate: pt},
+		}
+	}
+}
+
+func TestConfigurePodTemplate_LabelsAndAnnotations(t *testing.T) {
+	g := NewWithT(t)
+
+	sts := statefulSets(t, newOptions(t, podTemplateSpec(&ssdlokiv1.PodTemplateSpec{
+		Labels: map[string]string{
+			"team":                        "observability",
+			"app.kubernetes.io/component": "custom",
+		},
+		Annotations: map[string]string{
+			"example.com/owner":      "logs",
+			AnnotationLokiConfigHash: "custom",
+		},
+	})))
+
+	tpl := sts["loki-write"].Spec.Template
+	g.Expect(tpl.Labels).To(HaveKeyWithValue("team", "observability"))
+	g.Expect(tpl.Labels).To(HaveKeyWithValue("app.kubernetes.io/component", "write"))
+	g.Expect(tpl.Annotations).To(HaveKeyWithValue("example.com/owner", "logs"))
+	g.Expect(tpl.Annotations[AnnotationLokiConfigHash]).NotTo(Equal("custom"))
+
+	g.Expect(sts["loki-read"].Spec.Template.Labels).NotTo(HaveKey("team"))
+}
+
+func TestConfigurePodTemplate_Placement(t *testing.T) {
+	g := NewWithT(t)
+
+	toleration := corev1.Toleration{
+		Key:      "dedicated",
+		Operator: corev1.TolerationOpEqual,
+		Value:    "loki",
+		Effect:   corev1.TaintEffectNoSchedule,
+	}
+
+	sts := statefulSets(t, newOptions(t, podTemplateSpec(&ssdlokiv1.PodTemplateSpec{
+		NodeSelector:      map[string]string{"node-pool": "loki"},
+		Tolerations:       []corev1.Toleration{toleration},
+		PriorityClassName: "high-priority",
+	})))
+
+	spec := sts["loki-write"].Spec.Template.Spec
+	g.Expect(spec.NodeSelector).To(Equal(map[string]string{"node-pool": "loki"}))
+	g.Expect(spec.Tolerations).To(ConsistOf(toleration))
+	g.Expect(spec.PriorityClassName).To(Equal("high-priority"))
+}
+
+func TestConfigurePodTemplate_AffinityReplacesOnlySetParts(t *testing.T) {
+	g := NewWithT(t)
+
+	nodeAffinity := &corev1.NodeAffinity{
+		RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+			NodeSelectorTerms: []corev1.NodeSelectorTerm{
+				{
+					MatchExpressions: []corev1.NodeSelectorRequirement{
+						{Key: "node-pool", Operator: corev1.NodeSelectorOpIn, Values: []string{"loki"}},
+					},
+				},
+			},
+		},
+	}
+
+	generated := statefulSets(t, newOptions(t, nil))["loki-write"].Spec.Template.Spec.Affinity
+
+	sts := statefulSets(t, newOptions(t, podTemplateSpec(&ssdlokiv1.PodTemplateSpec{
+		Affinity: &corev1.Affinity{NodeAffinity: nodeAffinity},
+	})))
+	affinity := sts["loki-write"].Spec.Template.Spec.Affinity
+	g.Expect(affinity.NodeAffinity).To(Equal(nodeAffinity))
+	g.Expect(affinity.PodAntiAffinity).To(Equal(generated.PodAntiAffinity))
+
+	antiAffinity := &corev1.PodAntiAffinity{}
+	sts = statefulSets(t, newOptions(t, podTemplateSpec(&ssdlokiv1.PodTemplateSpec{
+		Affinity: &corev1.Affinity{PodAntiAffinity: antiAffinity},
+	})))
+	g.Expect(sts["loki-write"].Spec.Template.Spec.Affinity.PodAntiAffinity).To(Equal(antiAffinity))
+}
@@ -1,6 +1,8 @@
 package manifests
 
 import (
+	"fmt"
+
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	policyv1 "k8s.io/api/policy/v1"
@@ -9,10 +11,18 @@ import (
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/ssd-loki/loki-operator/internal/manifests/storage"
 )
 
 func BuildRead(opts Options) ([]client.Object, error) {
 	statefulset := NewReadStatefulSet(opts)
+	if err := storage.ConfigureStatefulSet(statefulset, opts.ObjectStorage); err != nil {
+		return nil, err
+	}
+	configureInternalTLS(statefulset, opts, ComponentRead)
+	configurePodTemplate(statefulset, opts.Stack.Spec.Template.Read)
+
 	objs := []client.Object{
 		statefulset,
 		NewLokiReadService(opts),
@@ -24,29 +34,30 @@ func BuildRead(opts Options) ([]client.Object, error) {
 }
 
 func NewReadStatefulSet(opts Options) *appsv1.StatefulSet {
-	readLabels := commonLabels("read", opts.Name)
-	memberListLabels := memberListLabels()
+	readLabels := commonLabels(opts.Name, ComponentRead)
+	memberListLabels := memberListLabels(opts.Name)
 
 	// 컨테이너 정의
 	container := corev1.Container{
 		Name:            "loki",
-		Image:           defaultImage,
+		Image:           opts.Image,
 		ImagePullPolicy: corev1.PullIfNotPresent,
 		Args: []string{
 			"-config.file=/etc/loki/config/config.yaml", //TODO
+			"-config.expand-env=true",
 			"-target=read",
 			"-legacy-read-mode=false",
-			"-common.compactor-grpc-address=loki-backend.default.svc.cluster.local:9095",
+			fmt.Sprintf("-common.compactor-grpc-address=%s:%d", fqdn(BackendName(opts.Name), opts.Namespace), opts.lokiGRPCPort()),
 		},
 		Ports: []corev1.ContainerPort{
 			{
 				Name:          lokiHTTPPortName,
-				ContainerPort: httpPort,
+				ContainerPort: opts.lokiHTTPPort(),
 				Protocol:      protocolTCP,
 			},
 			{
 				Name:          lokiGRPCPortName,
-				ContainerPort: grpcPort,
+				ContainerPort: opts.lokiGRPCPort(),
 				Protocol:      protocolTCP,
 			},
 			{
@@ -55,6 +66,10 @@ func NewReadStatefulSet(opts Options) *appsv1.StatefulSet {
 				Protocol:      protocolTCP,
 			},
 		},
+		Resources: corev1.ResourceRequirements{
+			Limits:   opts.ResourceRequirements.Read.Limits,
+			Requests: opts.ResourceRequirements.Read.Requests,
+		},
 		SecurityContext: &corev1.SecurityContext{
 			AllowPrivilegeEscalation: ptr.To(false),
 			Capabilities: &corev1.Capabilities{
@@ -66,7 +81,7 @@ func NewReadStatefulSet(opts Options) *appsv1.StatefulSet {
 			ProbeHandler: corev1.ProbeHandler{
 				HTTPGet: &corev1.HTTPGetAction{
 					Path: "/ready",
-					Port: intstr.IntOrString{IntVal: httpPort},
+					Port: intstr.FromInt32(opts.lokiHTTPPort()),
 				},
 			},
 			InitialDelaySeconds: 30,
@@ -90,7 +105,7 @@ func NewReadStatefulSet(opts Options) *appsv1.StatefulSet {
 
 	// PodSpec 정의
 	podSpec := corev1.PodSpec{
-		ServiceAccountName:            "loki",
+		ServiceAccountName:            serviceAccountName(opts.Name),
 		AutomountServiceAccountToken:  ptr.To(true),
 		EnableServiceLinks:            ptr.To(true),
 		TerminationGracePeriodSeconds: ptr.To(int64(300)),
@@ -106,9 +121,7 @@ func NewReadStatefulSet(opts Options) *appsv1.StatefulSet {
 				RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{
 					{
 						LabelSelector: &metav1.LabelSelector{
-							MatchLabels: map[string]string{
-								"app.kubernetes.io/component": "read",
-							},
+							MatchLabels: readLabels,
 						},
 						TopologyKey: "kubernetes.io/hostname",
 					},
@@ -121,7 +134,7 @@ func NewReadStatefulSet(opts Options) *appsv1.StatefulSet {
 				VolumeSource: corev1.VolumeSource{
 					ConfigMap: &corev1.ConfigMapVolumeSource{
 						LocalObjectReference: corev1.LocalObjectReference{
-							Name: "loki",
+							Name: configMapName(opts.Name),
 						},
 						Items: []corev1.KeyToPath{
 							{
@@ -137,7 +150,7 @@ func NewReadStatefulSet(opts Options) *appsv1.StatefulSet {
 				VolumeSource: corev1.VolumeSource{
 					ConfigMap: &corev1.ConfigMapVolumeSource{
 						LocalObjectReference: corev1.LocalObjectReference{
-							Name: "loki-runtime",
+							Name: runtimeConfigMapName(opts.Name),
 						},
 					},
 				},
@@ -158,19 +171,20 @@ func NewReadStatefulSet(opts Options) *appsv1.StatefulSet {
 			APIVersion: appsv1.SchemeGroupVersion.String(),
 		},
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      "loki-read",
-			Namespace: "default",
+			Name:      ReadName(opts.Name),
+			Namespace: opts.Namespace,
 			Labels:    labels.Merge(memberListLabels, readLabels),
 		},
 		Spec: appsv1.StatefulSetSpec{
-			Replicas: ptr.To(int32(3)),
+			Replicas: ptr.To(*opts.Stack.Spec.Template.Read.Replicas),
 			Selector: &metav1.LabelSelector{
 				MatchLabels: readLabels,
 			},
-			ServiceName: "loki-read-headless",
+			ServiceName: headlessServiceName(ReadName(opts.Name)),
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels: labels.Merge(memberListLabels, readLabels),
+					Labels:      labels.Merge(memberListLabels, readLabels),
+					Annotations: podAnnotations(opts),
 				},
 				Spec: podSpec,
 			},
@@ -186,6 +200,7 @@ func NewReadStatefulSet(opts Options) *appsv1.StatefulSet {
 						AccessModes: []corev1.PersistentVolumeAccessMode{
 							corev1.ReadWriteOnce,
 						},
+						StorageClassName: storageClassName(opts.Stack.Spec.Template.Read),
 						Resources: corev1.VolumeResourceRequirements{
 							Requests: corev1.ResourceList{
 								corev1.ResourceStorage: opts.ResourceRequirements.Read.PVCSize,
@@ -204,8 +219,8 @@ func NewReadStatefulSet(opts Options) *appsv1.StatefulSet {
 }
 
 func NewLokiReadService(opts Options) *corev1.Service {
-	serviceName := "loki-read"
-	readLabels := commonLabels(opts.Name, "read")
+	serviceName := ReadName(opts.Name)
+	readLabels := commonLabels(opts.Name, ComponentRead)
 
 	// Return the new service object
 	return &corev1.Service{
@@ -215,7 +230,7 @@ func NewLokiReadService(opts Options) *corev1.Service {
 		},
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      serviceName,
-			Namespace: "default",
+			Namespace: opts.Namespace,
 			Labels:    readLabels,
 		},
 		Spec: corev1.ServiceSpec{
@@ -223,15 +238,15 @@ func NewLokiReadService(opts Options) *corev1.Service {
 			Ports: []corev1.ServicePort{
 				{
 					Name:       lokiHTTPPortName,
-					Port:       httpPort,
+					Port:       opts.lokiHTTPPort(),
 					Protocol:   protocolTCP,
-					TargetPort: intstr.IntOrString{StrVal: lokiHTTPPortName},
+					TargetPort: intstr.FromString(lokiHTTPPortName),
 				},
 				{
 					Name:       lokiGRPCPortName,
-					Port:       grpcPort,
+					Port:       opts.lokiGRPCPort(),
 					Protocol:   protocolTCP,
-					TargetPort: intstr.IntOrString{StrVal: lokiGRPCPortName},
+					TargetPort: intstr.FromString(lokiGRPCPortName),
 				},
 			},
 			Selector: readLabels,
@@ -239,10 +254,10 @@ func NewLokiReadService(opts Options) *corev1.Service {
 	}
 }
 
-// NewLokireadService creates a k8s service for the Loki read component
+// NewLokiReadHeadlessService creates a headless k8s service for the Loki read component
 func NewLokiReadHeadlessService(opts Options) *corev1.Service {
-	serviceName := "loki-read"
-	readLabels := commonLabels(opts.Name, "read")
+	serviceName := headlessServiceName(ReadName(opts.Name))
+	readLabels := commonLabels(opts.Name, ComponentRead)
 	// Return the new service object
 	return &corev1.Service{
 		TypeMeta: metav1.TypeMeta{
@@ -251,7 +266,7 @@ func NewLokiReadHeadlessService(opts Options) *corev1.Service {
 		},
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      serviceName,
-			Namespace: "default",
+			Namespace: opts.Namespace,
 			Labels:    labels.Merge(readLabels, headlessServiceLabels()),
 		},
 		Spec: corev1.ServiceSpec{
@@ -260,15 +275,15 @@ func NewLokiReadHeadlessService(opts Options) *corev1.Service {
 			Ports: []corev1.ServicePort{
 				{
 					Name:       lokiHTTPPortName,
-					Port:       httpPort,
+					Port:       opts.lokiHTTPPort(),
 					Protocol:   protocolTCP,
-					TargetPort: intstr.IntOrString{StrVal: lokiHTTPPortName},
+					TargetPort: intstr.FromString(lokiHTTPPortName),
 				},
 				{
 					Name:       lokiGRPCPortName,
-					Port:       grpcPort,
+					Port:       opts.lokiGRPCPort(),
 					Protocol:   protocolTCP,
-					TargetPort: intstr.IntOrString{StrVal: lokiGRPCPortName},
+					TargetPort: intstr.FromString(lokiGRPCPortName),
 				},
 			},
 			Selector: readLabels,
@@ -276,10 +291,10 @@ func NewLokiReadHeadlessService(opts Options) *corev1.Service {
 	}
 }
 
-// NewQuerierPodDisruptionBudget returns a PodDisruptionBudget for the LokiStack querier pods.
+// NewReadPodDisruptionBudget returns a PodDisruptionBudget for the Loki read pods.
 func NewReadPodDisruptionBudget(opts Options) *policyv1.PodDisruptionBudget {
-	name := "loki-read"
-	labels := commonLabels(opts.Name, "read")
+	name := ReadName(opts.Name)
+	labels := commonLabels(opts.Name, ComponentRead)
 
 	return &policyv1.PodDisruptionBudget{
 		TypeMeta: metav1.TypeMeta{
@@ -288,7 +303,7 @@ func NewReadPodDisruptionBudget(opts Options) *policyv1.PodDisruptionBudget {
 		},
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      name,
-			Namespace: "default",
+			Namespace: opts.Namespace,
 			Labels:    labels,
 		},
 		Spec: policyv1.PodDisruptionBudgetSpec{
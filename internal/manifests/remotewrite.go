@@ -0,0 +1,151 @@
+package manifests
+
+import (
+	"crypto/sha1"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	ssdlokiv1 "github.com/ssd-loki/loki-operator/api/v1"
+	"github.com/ssd-loki/loki-operator/internal/manifests/internal/config"
+)
+
+const (
+	// rulerWALDir keeps the write-ahead log of the remote write on the volume of
+	// the backend tier, so that unsent samples survive restarts.
+	rulerWALDir = "/var/loki/ruler-wal"
+
+	// RulerSecretUsernameKey, RulerSecretPasswordKey and RulerSecretBearerTokenKey
+	// are the fields of the authorization Secret of the ruler remote write.
+	RulerSecretUsernameKey    = "username"
+	RulerSecretPasswordKey    = "password"
+	RulerSecretBearerTokenKey = "bearer_token"
+
+	envRulerRemoteWriteUsername    = "RULER_REMOTE_WRITE_USERNAME"
+	envRulerRemoteWritePassword    = "RULER_REMOTE_WRITE_PASSWORD"
+	envRulerRemoteWriteBearerToken = "RULER_REMOTE_WRITE_BEARER_TOKEN"
+)
+
+// RemoteWriteEnabled reports whether the ruler of the stack sends the results of
+// recording rules to a remote write endpoint.
+func RemoteWriteEnabled(stack *ssdlokiv1.SsdLoki) bool {
+	r := stack.Spec.Ruler
+	return r != nil && r.RemoteWrite != nil && r.RemoteWrite.Enabled
+}
+
+// RulerSecretName returns the name of the authorization Secret of the ruler
+// remote write, or an empty string if none is used.
+func RulerSecretName(stack *ssdlokiv1.SsdLoki) string {
+	if !RemoteWriteEnabled(stack) {
+		return ""
+	}
+	c := stack.Spec.Ruler.RemoteWrite.Client
+	if c.AuthorizationType == "" {
+		return ""
+	}
+	return c.AuthorizationSecretName
+}
+
+// rulerSecretHash returns a hash of the credentials of the ruler remote write,
+// or an empty string if none are used.
+func rulerSecretHash(s *RulerSecret) string {
+	if s == nil {
+		return ""
+	}
+	h := sha1.New()
+	for _, v := range []string{s.Username, s.Password, s.BearerToken} {
+		_, _ = h.Write([]byte(v))
+		_, _ = h.Write([]byte{0})
+	}
+	return fmt.Sprintf("%x", h.Sum(nil))
+}
+
+// remoteWriteConfigOptions returns the remote write options of the ruler, with
+// the credentials referenced through envPlaceholder.
+func remoteWriteConfigOptions(opts Options) *config.RemoteWriteConfig {
+	if !RemoteWriteEnabled(&opts.Stack) {
+		return nil
+	}
+
+	rw := opts.Stack.Spec.Ruler.RemoteWrite
+	c := rw.Client
+	cfg := &config.RemoteWriteConfig{
+		Enabled:       true,
+		RefreshPeriod: string(rw.RefreshPeriod),
+		WALDirectory:  rulerWALDir,
+		Client: &config.RemoteWriteClientConfig{
+			Name:            c.Name,
+			URL:             c.URL,
+			RemoteTimeout:   string(c.Timeout),
+			Headers:         c.Headers,
+			ProxyURL:        c.ProxyURL,
+			FollowRedirects: c.FollowRedirects,
+		},
+	}
+
+	switch c.AuthorizationType {
+	case ssdlokiv1.RemoteWriteAuthBasic:
+		cfg.Client.BasicAuthUsername = envPlaceholder(envRulerRemoteWriteUsername)
+		cfg.Client.BasicAuthPassword = envPlaceholder(envRulerRemoteWritePassword)
+	case ssdlokiv1.RemoteWriteAuthBearer:
+		cfg.Client.BearerToken = envPlaceholder(envRulerRemoteWriteBearerToken)
+	}
+
+	if q := rw.Queue; q != nil {
+		cfg.Queue = &config.RemoteWriteQueueConfig{
+			Capacity:          q.Capacity,
+			MaxShards:         q.MaxShards,
+			MinShards:         q.MinShards,
+			MaxSamplesPerSend: q.MaxSamplesPerSend,
+			BatchSendDeadline: string(q.BatchSendDeadline),
+			MinBackOffPeriod:  string(q.MinBackOffPeriod),
+			MaxBackOffPeriod:  string(q.MaxBackOffPeriod),
+		}
+	}
+
+	for _, rc := range rw.RelabelConfigs {
+		cfg.RelabelConfigs = append(cfg.RelabelConfigs, relabelConfig(rc))
+	}
+
+	return cfg
+}
+
+// configureRemoteWrite exposes the credentials of the ruler remote write to the
+// pods of the ruler as environment variables.
+func configureRemoteWrite(sts *appsv1.StatefulSet, opts Options) {
+	name := RulerSecretName(&opts.Stack)
+	if name == "" {
+		return
+	}
+
+	var env map[string]string
+	switch opts.Stack.Spec.Ruler.RemoteWrite.Client.AuthorizationType {
+	case ssdlokiv1.RemoteWriteAuthBasic:
+		env = map[string]string{
+			envRulerRemoteWriteUsername: RulerSecretUsernameKey,
+			envRulerRemoteWritePassword: RulerSecretPasswordKey,
+		}
+	case ssdlokiv1.RemoteWriteAuthBearer:
+		env = map[string]string{
+			envRulerRemoteWriteBearerToken: RulerSecretBearerTokenKey,
+		}
+	}
+
+	c := &sts.Spec.Template.Spec.Containers[0]
+	for _, v := range []string{envRulerRemoteWriteUsername, envRulerRemoteWritePassword, envRulerRemoteWriteBearerToken} {
+		key, ok := env[v]
+		if !ok {
+			continue
+		}
+		c.Env = append(c.Env, corev1.EnvVar{
+			Name: v,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: name},
+					Key:                  key,
+				},
+			},
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package manifests
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/yaml"
+
+	ssdlokiv1 "github.com/ssd-loki/loki-operator/api/v1"
+	"github.com/ssd-loki/loki-operator/internal/manifests/internal/config"
+)
+
+func remoteWriteSpec(authType ssdlokiv1.RemoteWriteAuthType) func(*ssdlokiv1.SsdLokiSpec) {
+	return func(spec *ssdlokiv1.SsdLokiSpec) {
+		spec.Ruler = &ssdlokiv1.RulerConfig{
+			RemoteWrite: &ssdlokiv1.RemoteWriteSpec{
+				Enabled:       true,
+				RefreshPeriod: "10s",
+				Client: ssdlokiv1.RemoteWriteClientSpec{
+					Name:                    "prometheus",
+					URL:                     "https://prometheus.monitoring.svc:9090/api/v1/write",
+					Timeout:                 "30s",
+					Headers:                 map[string]string{"X-Scope-OrgID": "loki"},
+					AuthorizationType:       authType,
+					AuthorizationSecretName: "remote-write",
+				},
+				Queue: &ssdlokiv1.RemoteWriteQueueSpec{
+					Capacity:         2500,
+					MaxShards:        10,
+					MinBackOffPeriod: "30ms",
+				},
+				RelabelConfigs: []ssdlokiv1.RelabelConfigSpec{
+					{SourceLabels: []string{"__name__"}, Regex: "debug_.*", Action: "drop"},
+				},
+			},
+		}
+	}
+}
+
+func TestLokiConfigMap_RemoteWrite(t *testing.T) {
+	g := NewWithT(t)
+
+	cm, err := LokiConfigMap(newOptions(t, remoteWriteSpec(ssdlokiv1.RemoteWriteAuthBasic)))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	cfg := map[string]interface{}{}
+	g.Expect(yaml.Unmarshal([]byte(cm.Data[config.LokiConfigFileName]), &cfg)).To(Succeed())
+
+	g.Expect(cfg["ruler"]).To(And(
+		HaveKeyWithValue("wal", HaveKeyWithValue("dir", "/var/loki/ruler-wal")),
+		HaveKeyWithValue("remote_write", And(
+			HaveKeyWithValue("enabled", true),
+			HaveKeyWithValue("config_refresh_period", "10s"),
+			HaveKeyWithValue("clients", HaveKeyWithValue("prometheus", And(
+				HaveKeyWithValue("url", "https://prometheus.monitoring.svc:9090/api/v1/write"),
+				HaveKeyWithValue("remote_timeout", "30s"),
+				HaveKeyWithValue("headers", HaveKeyWithValue("X-Scope-OrgID", "loki")),
+				HaveKeyWithValue("basic_auth", And(
+					HaveKeyWithValue("username", "${RULER_REMOTE_WRITE_USERNAME}"),
+					HaveKeyWithValue("password", "${RULER_REMOTE_WRITE_PASSWORD}"),
+				)),
+				Not(HaveKey("authorization")),
+				HaveKeyWithValue("queue_config", And(
+					HaveKeyWithValue("capacity", BeNumerically("==", 2500)),
+					HaveKeyWithValue("max_shards", BeNumerically("==", 10)),
+					HaveKeyWithValue("min_backoff", "30ms"),
+					Not(HaveKey("min_shards")),
+				)),
+				HaveKeyWithValue("write_relabel_configs", ConsistOf(And(
+					HaveKeyWithValue("source_labels", ConsistOf("__name__")),
+					HaveKeyWithValue("regex", "debug_.*"),
+					HaveKeyWithValue("action", "drop"),
+				))),
+			))),
+		)),
+	))
+
+	cm, err = LokiConfigMap(newOptions(t, remoteWriteSpec(ssdlokiv1.RemoteWriteAuthBearer)))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(cm.Data[config.LokiConfigFileName]).To(ContainSubstring("credentials: ${RULER_REMOTE_WRITE_BEARER_TOKEN}"))
+	g.Expect(cm.Data[config.LokiConfigFileName]).NotTo(ContainSubstring("basic_auth:"))
+}
+
+func TestLokiConfigMap_RemoteWriteDisabled(t *testing.T) {
+	g := NewWithT(t)
+
+	opts := newOptions(t, remoteWriteSpec(ssdlokiv1.RemoteWriteAuthBasic))
+	opts.Stack.Spec.Ruler.RemoteWrite.Enabled = false
+
+	cm, err := LokiConfigMap(opts)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(cm.Data[config.LokiConfigFileName]).NotTo(ContainSubstring("remote_write"))
+	g.Expect(cm.Data[config.LokiConfigFileName]).NotTo(ContainSubstring("ruler-wal"))
+}
+
+func TestBuildAll_RemoteWriteCredentialsFromSecret(t *testing.T) {
+	g := NewWithT(t)
+
+	sts := statefulSets(t, newOptions(t, remoteWriteSpec(ssdlokiv1.RemoteWriteAuthBasic)))
+	env := sts["loki-backend"].Spec.Template.Spec.Containers[0].Env
+
+	g.Expect(env).To(ContainElements(
+		And(
+			HaveField("Name", "RULER_REMOTE_WRITE_USERNAME"),
+			HaveField("ValueFrom.SecretKeyRef.Name", "remote-write"),
+			HaveField("ValueFrom.SecretKeyRef.Key", "username"),
+		),
+		And(
+			HaveField("Name", "RULER_REMOTE_WRITE_PASSWORD"),
+			HaveField("ValueFrom.SecretKeyRef.Name", "remote-write"),
+			HaveField("ValueFrom.SecretKeyRef.Key", "password"),
+		),
+	))
+	g.Expect(env).NotTo(ContainElement(HaveField("Name", "RULER_REMOTE_WRITE_BEARER_TOKEN")))
+	g.Expect(sts["loki-read"].Spec.Template.Spec.Containers[0].Env).NotTo(ContainElement(HaveField("Name", "RULER_REMOTE_WRITE_USERNAME")))
+}
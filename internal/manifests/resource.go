@@ -3,6 +3,9 @@ package manifests
 import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
+
+	ssdlokiv1 "github.com/ssd-loki/loki-operator/api/v1"
 )
 
 // ComponentResources is a map of component->requests/limits
@@ -19,3 +22,29 @@ type ResourceRequirements struct {
 	PVCSize         resource.Quantity
 	PDBMinAvailable int
 }
+
+// resourceRequirementsFor returns the requirements of a tier from its template.
+func resourceRequirementsFor(spec *ssdlokiv1.SsdLokiComponentSpec) ResourceRequirements {
+	var rr ResourceRequirements
+	if spec == nil {
+		return rr
+	}
+
+	if spec.Resources != nil {
+		rr.Limits = spec.Resources.Limits
+		rr.Requests = spec.Resources.Requests
+	}
+	if spec.VolumeSize != nil {
+		rr.PVCSize = spec.VolumeSize.DeepCopy()
+	}
+
+	return rr
+}
+
+// storageClassName returns the storage class of a tier, or nil to use the cluster default.
+func storageClassName(spec *ssdlokiv1.SsdLokiComponentSpec) *string {
+	if spec == nil || spec.StorageClassName == "" {
+		return nil
+	}
+	return ptr.To(spec.StorageClassName)
+}
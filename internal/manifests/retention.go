@@ -0,0 +1,20 @@
+package manifests
+
+import (
+	"github.com/ssd-loki/loki-operator/internal/manifests/internal/config"
+)
+
+// retentionConfigOptions returns the retention options of the compactor. The
+// log deletion requests are kept in the object store of the stack.
+func retentionConfigOptions(opts Options) config.RetentionOptions {
+	r := opts.Stack.Spec.Retention
+	if r == nil || !r.Enabled {
+		return config.RetentionOptions{}
+	}
+
+	return config.RetentionOptions{
+		Enabled:            true,
+		DeleteWorkerCount:  uint(r.DeleteWorkerCount),
+		DeleteRequestStore: string(opts.ObjectStorage.SharedStore),
+	}
+}
@@ -0,0 +1,91 @@
+package manifests
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/yaml"
+
+	ssdlokiv1 "github.com/ssd-loki/loki-operator/api/v1"
+	"github.com/ssd-loki/loki-operator/internal/manifests/internal/config"
+)
+
+func retentionSpec(spec *ssdlokiv1.SsdLokiSpec) {
+	spec.Common = &ssdlokiv1.CommonConfig{
+		Storage: &ssdlokiv1.CommonStorage{
+			S3: &ssdlokiv1.S3Config{BucketNames: "logs"},
+		},
+	}
+	spec.Retention = &ssdlokiv1.RetentionSpec{
+		Enabled:           true,
+		DeleteWorkerCount: 50,
+		RetentionLimitSpec: ssdlokiv1.RetentionLimitSpec{
+			Period: "30d",
+			Streams: []ssdlokiv1.RetentionStreamSpec{
+				{Selector: `{namespace="audit"}`, Priority: 1, Period: "1y"},
+			},
+		},
+	}
+	spec.Overrides = map[string]ssdlokiv1.PerTenantLimitsConfig{
+		"team-a": {
+			Retention: &ssdlokiv1.RetentionLimitSpec{
+				Period: "7d",
+				Streams: []ssdlokiv1.RetentionStreamSpec{
+					{Selector: `{app="debug"}`, Period: "1d"},
+				},
+			},
+		},
+	}
+}
+
+func TestLokiConfigMap_Retention(t *testing.T) {
+	g := NewWithT(t)
+
+	cm, err := LokiConfigMap(newOptions(t, retentionSpec))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	cfg := map[string]interface{}{}
+	g.Expect(yaml.Unmarshal([]byte(cm.Data[config.LokiConfigFileName]), &cfg)).To(Succeed())
+
+	g.Expect(cfg["compactor"]).To(And(
+		HaveKeyWithValue("retention_enabled", true),
+		HaveKeyWithValue("delete_request_store", "s3"),
+		HaveKeyWithValue("retention_delete_worker_count", BeNumerically("==", 50)),
+	))
+	g.Expect(cfg["limits_config"]).To(And(
+		HaveKeyWithValue("retention_period", "30d"),
+		HaveKeyWithValue("retention_stream", ConsistOf(And(
+			HaveKeyWithValue("selector", `{namespace="audit"}`),
+			HaveKeyWithValue("priority", BeNumerically("==", 1)),
+			HaveKeyWithValue("period", "1y"),
+		))),
+	))
+}
+
+func TestLokiConfigMap_RetentionDisabled(t *testing.T) {
+	g := NewWithT(t)
+
+	cm, err := LokiConfigMap(newOptions(t, nil))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(cm.Data[config.LokiConfigFileName]).NotTo(ContainSubstring("compactor:"))
+	g.Expect(cm.Data[config.LokiConfigFileName]).NotTo(ContainSubstring("retention_period"))
+}
+
+func TestLokiRuntimeConfigMap_RetentionOverrides(t *testing.T) {
+	g := NewWithT(t)
+
+	cm, err := LokiRuntimeConfigMap(newOptions(t, retentionSpec))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	cfg := map[string]interface{}{}
+	g.Expect(yaml.Unmarshal([]byte(cm.Data[config.LokiRuntimeConfigFileName]), &cfg)).To(Succeed())
+
+	overrides := cfg["overrides"].(map[string]interface{})
+	g.Expect(overrides).To(HaveKeyWithValue("team-a", And(
+		HaveKeyWithValue("retention_period", "7d"),
+		HaveKeyWithValue("retention_stream", ConsistOf(And(
+			HaveKeyWithValue("selector", `{app="debug"}`),
+			HaveKeyWithValue("period", "1d"),
+		))),
+	)))
+}
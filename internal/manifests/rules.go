@@ -0,0 +1,233 @@
+package manifests
+
+import (
+	"fmt"
+	"path"
+	"sort"
+
+	"github.com/ViaQ/logerr/kverrors"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	ssdlokiv1 "github.com/ssd-loki/loki-operator/api/v1"
+	"github.com/ssd-loki/loki-operator/internal/manifests/internal/config"
+)
+
+const (
+	rulesVolumeName = "rules"
+	// rulesMountDir is the directory of the local rule storage of the ruler. It
+	// holds a directory of rule files per tenant.
+	rulesMountDir = "/etc/loki/rules"
+	// rulerScratchDir is where the ruler writes the rule files it evaluates.
+	rulerScratchDir = "/var/loki/scratch"
+
+	// rulesConfigMapMaxSize is the size of the rule files a ConfigMap holds before
+	// the next one is started, leaving room below the 1MiB object size limit.
+	rulesConfigMapMaxSize = 1000 * 1024
+
+	componentRules = "rules"
+)
+
+// rulesFile is a rule file of a tenant.
+type rulesFile struct {
+	tenant  string
+	name    string
+	content string
+}
+
+// rulesShard is a ConfigMap holding rule files of a single tenant.
+type rulesShard struct {
+	tenant string
+	files  map[string]string
+	size   int
+}
+
+// RulesEnabled reports whether the ruler of the stack loads AlertingRules and
+// RecordingRules.
+func RulesEnabled(stack *ssdlokiv1.SsdLoki) bool {
+	return stack.Spec.Rules != nil && stack.Spec.Rules.Enabled
+}
+
+// RulesConfigMapName is the name of the i-th ConfigMap holding the rule files of a stack.
+func RulesConfigMapName(stackName string, i int) string {
+	return fmt.Sprintf("%s-rules-%d", stackName, i)
+}
+
+// RulesConfigMapLabels returns the labels of the ConfigMaps holding the rule files of a stack.
+func RulesConfigMapLabels(stackName string) map[string]string {
+	return commonLabels(stackName, componentRules)
+}
+
+// BuildRules returns the ConfigMaps holding the rule files of the selected
+// AlertingRules and RecordingRules. The files of a tenant are spread over as
+// many ConfigMaps as needed to stay below the object size limit, and no
+// ConfigMap holds the files of more than one tenant.
+func BuildRules(opts Options) ([]client.Object, error) {
+	if !RulesEnabled(&opts.Stack) {
+		return nil, nil
+	}
+
+	shards, err := rulesShards(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	objs := make([]client.Object, 0, len(shards))
+	for i, s := range shards {
+		objs = append(objs, &corev1.ConfigMap{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "ConfigMap",
+				APIVersion: corev1.SchemeGroupVersion.String(),
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      RulesConfigMapName(opts.Name, i),
+				Namespace: opts.Namespace,
+				Labels:    RulesConfigMapLabels(opts.Name),
+			},
+			Data: s.files,
+		})
+	}
+	return objs, nil
+}
+
+// rulesConfigMapNames returns the names of the rules ConfigMaps keyed by the
+// tenant whose files they hold.
+func rulesConfigMapNames(opts Options) (map[string][]string, error) {
+	shards, err := rulesShards(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	names := map[string][]string{}
+	for i, s := range shards {
+		names[s.tenant] = append(names[s.tenant], RulesConfigMapName(opts.Name, i))
+	}
+	return names, nil
+}
+
+func rulesShards(opts Options) ([]rulesShard, error) {
+	files, err := rulesFiles(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var shards []rulesShard
+	for _, f := range files {
+		last := len(shards) - 1
+		if last < 0 || shards[last].tenant != f.tenant || shards[last].size+len(f.content) > rulesConfigMapMaxSize {
+			shards = append(shards, rulesShard{tenant: f.tenant, files: map[string]string{}})
+			last++
+		}
+		shards[last].files[f.name] = f.content
+		shards[last].size += len(f.content)
+	}
+	return shards, nil
+}
+
+// rulesFiles renders a rule file per AlertingRule and RecordingRule, sorted by
+// tenant and file name so that the sharding is stable.
+func rulesFiles(opts Options) ([]rulesFile, error) {
+	var files []rulesFile
+
+	for _, r := range opts.AlertingRules {
+		content, err := yaml.Marshal(map[string]interface{}{"groups": r.Spec.Groups})
+		if err != nil {
+			return nil, kverrors.Wrap(err, "failed to marshal alerting rule", "name", client.ObjectKeyFromObject(&r))
+		}
+		files = append(files, rulesFile{
+			tenant:  r.Spec.TenantID,
+			name:    fmt.Sprintf("alerting-%s-%s.yaml", r.Namespace, r.Name),
+			content: string(content),
+		})
+	}
+
+	for _, r := range opts.RecordingRules {
+		content, err := yaml.Marshal(map[string]interface{}{"groups": r.Spec.Groups})
+		if err != nil {
+			return nil, kverrors.Wrap(err, "failed to marshal recording rule", "name", client.ObjectKeyFromObject(&r))
+		}
+		files = append(files, rulesFile{
+			tenant:  r.Spec.TenantID,
+			name:    fmt.Sprintf("recording-%s-%s.yaml", r.Namespace, r.Name),
+			content: string(content),
+		})
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		if files[i].tenant != files[j].tenant {
+			return files[i].tenant < files[j].tenant
+		}
+		return files[i].name < files[j].name
+	})
+	return files, nil
+}
+
+// rulesConfigOptions returns the ruler options of the Loki config.
+func rulesConfigOptions(opts Options) config.RulesOptions {
+	if !RulesEnabled(&opts.Stack) {
+		return config.RulesOptions{}
+	}
+	return config.RulesOptions{
+		Enabled:          true,
+		Directory:        rulesMountDir,
+		ScratchDirectory: rulerScratchDir,
+	}
+}
+
+// configureRules mounts the rules ConfigMaps of every tenant into the directory
+// of the tenant below the local rule storage of the ruler. A projected volume
+// per tenant merges the files of all its ConfigMaps. The storage directory is
+// an emptyDir, so that it exists while no rules are selected.
+func configureRules(sts *appsv1.StatefulSet, opts Options) {
+	if !RulesEnabled(&opts.Stack) {
+		return
+	}
+
+	spec := &sts.Spec.Template.Spec
+	spec.Volumes = append(spec.Volumes, corev1.Volume{
+		Name: rulesVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+		},
+	})
+	c := &spec.Containers[0]
+	c.VolumeMounts = append(c.VolumeMounts, corev1.VolumeMount{
+		Name:      rulesVolumeName,
+		MountPath: rulesMountDir,
+	})
+
+	tenants := make([]string, 0, len(opts.RulesConfigMapNames))
+	for tenant := range opts.RulesConfigMapNames {
+		tenants = append(tenants, tenant)
+	}
+	sort.Strings(tenants)
+
+	for i, tenant := range tenants {
+		// Tenant IDs are not necessarily valid volume names.
+		name := fmt.Sprintf("%s-%d", rulesVolumeName, i)
+
+		var sources []corev1.VolumeProjection
+		for _, cm := range opts.RulesConfigMapNames[tenant] {
+			sources = append(sources, corev1.VolumeProjection{
+				ConfigMap: &corev1.ConfigMapProjection{
+					LocalObjectReference: corev1.LocalObjectReference{Name: cm},
+				},
+			})
+		}
+
+		spec.Volumes = append(spec.Volumes, corev1.Volume{
+			Name: name,
+			VolumeSource: corev1.VolumeSource{
+				Projected: &corev1.ProjectedVolumeSource{Sources: sources},
+			},
+		})
+		c.VolumeMounts = append(c.VolumeMounts, corev1.VolumeMount{
+			Name:      name,
+			ReadOnly:  true,
+			MountPath: path.Join(rulesMountDir, tenant),
+		})
+	}
+}
@@ -0,0 +1,183 @@
+package manifests
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	ssdlokiv1 "github.com/ssd-loki/loki-operator/api/v1"
+)
+
+func alertingRule(name, tenant, expr string) ssdlokiv1.AlertingRule {
+	return ssdlokiv1.AlertingRule{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns"},
+		Spec: ssdlokiv1.AlertingRuleSpec{
+			TenantID: tenant,
+			Groups: []*ssdlokiv1.AlertingRuleGroup{
+				{
+					Name:     "errors",
+					Interval: "1m",
+					Rules: []*ssdlokiv1.AlertingRuleGroupSpec{
+						{
+							Alert:  "HighErrorRate",
+							Expr:   expr,
+							For:    "5m",
+							Labels: map[string]string{"severity": "warning"},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func recordingRule(name, tenant string) ssdlokiv1.RecordingRule {
+	return ssdlokiv1.RecordingRule{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns"},
+		Spec: ssdlokiv1.RecordingRuleSpec{
+			TenantID: tenant,
+			Groups: []*ssdlokiv1.RecordingRuleGroup{
+				{
+					Name: "rates",
+					Rules: []*ssdlokiv1.RecordingRuleGroupSpec{
+						{Record: "app:lines:rate1m", Expr: `sum(rate({app="foo"}[1m]))`},
+					},
+				},
+			},
+		},
+	}
+}
+
+func rulesOptions(t *testing.T, alerting []ssdlokiv1.AlertingRule, recording []ssdlokiv1.RecordingRule) Options {
+	opts := newOptions(t, func(spec *ssdlokiv1.SsdLokiSpec) {
+		spec.Rules = &ssdlokiv1.RulesSpec{Enabled: true}
+	})
+	opts.AlertingRules = alerting
+	opts.RecordingRules = recording
+	return opts
+}
+
+func rulesConfigMaps(t *testing.T, opts Options) []*corev1.ConfigMap {
+	objs, err := BuildRules(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cms := make([]*corev1.ConfigMap, 0, len(objs))
+	for _, obj := range objs {
+		cms = append(cms, obj.(*corev1.ConfigMap))
+	}
+	return cms
+}
+
+func TestBuildRules_ConfigMapPerTenant(t *testing.T) {
+	g := NewWithT(t)
+
+	opts := rulesOptions(t,
+		[]ssdlokiv1.AlertingRule{
+			alertingRule("errors", "team-b", `sum(rate({app="foo"} |= "error" [5m])) > 10`),
+			alertingRule("errors", "team-a", `sum(rate({app="bar"} |= "error" [5m])) > 10`),
+		},
+		[]ssdlokiv1.RecordingRule{recordingRule("rates", "team-a")},
+	)
+
+	cms := rulesConfigMaps(t, opts)
+	g.Expect(cms).To(HaveLen(2))
+
+	g.Expect(cms[0].Name).To(Equal("loki-rules-0"))
+	g.Expect(cms[0].Labels).To(Equal(RulesConfigMapLabels("loki")))
+	g.Expect(cms[0].Data).To(HaveKey("alerting-ns-errors.yaml"))
+	g.Expect(cms[0].Data).To(HaveKey("recording-ns-rates.yaml"))
+	g.Expect(cms[0].Data["alerting-ns-errors.yaml"]).To(ContainSubstring(`{app="bar"}`))
+	g.Expect(cms[1].Name).To(Equal("loki-rules-1"))
+	g.Expect(cms[1].Data["alerting-ns-errors.yaml"]).To(ContainSubstring(`{app="foo"}`))
+
+	var file map[string]interface{}
+	g.Expect(yaml.Unmarshal([]byte(cms[0].Data["alerting-ns-errors.yaml"]), &file)).To(Succeed())
+	g.Expect(file).To(HaveKeyWithValue("groups", ContainElement(And(
+		HaveKeyWithValue("name", "errors"),
+		HaveKeyWithValue("interval", "1m"),
+		HaveKeyWithValue("rules", ContainElement(And(
+			HaveKeyWithValue("alert", "HighErrorRate"),
+			HaveKeyWithValue("for", "5m"),
+			HaveKeyWithValue("labels", HaveKeyWithValue("severity", "warning")),
+		))),
+	))))
+}
+
+func TestBuildRules_ShardsLargeTenants(t *testing.T) {
+	g := NewWithT(t)
+
+	expr := strings.Repeat("x", rulesConfigMapMaxSize/3)
+	opts := rulesOptions(t, []ssdlokiv1.AlertingRule{
+		alertingRule("a", "team-a", expr),
+		alertingRule("b", "team-a", expr),
+		alertingRule("c", "team-a", expr),
+		alertingRule("d", "team-a", expr),
+	}, nil)
+
+	cms := rulesConfigMaps(t, opts)
+	g.Expect(cms).To(HaveLen(2))
+	g.Expect(cms[0].Data).To(HaveLen(2))
+	g.Expect(cms[1].Data).To(HaveLen(2))
+
+	names, err := rulesConfigMapNames(opts)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(names).To(Equal(map[string][]string{"team-a": {"loki-rules-0", "loki-rules-1"}}))
+}
+
+func TestBuildAll_MountsRulesPerTenant(t *testing.T) {
+	g := NewWithT(t)
+
+	opts := rulesOptions(t, []ssdlokiv1.AlertingRule{
+		alertingRule("errors", "team-b", "expr"),
+		alertingRule("errors", "team-a", "expr"),
+	}, nil)
+
+	backend := statefulSets(t, opts)["loki-backend"]
+	spec := backend.Spec.Template.Spec
+
+	g.Expect(spec.Volumes).To(ContainElement(And(
+		HaveField("Name", "rules"),
+		HaveField("VolumeSource.EmptyDir", Not(BeNil())),
+	)))
+	g.Expect(spec.Volumes).To(ContainElement(And(
+		HaveField("Name", "rules-1"),
+		HaveField("VolumeSource.Projected.Sources", ConsistOf(
+			HaveField("ConfigMap.Name", "loki-rules-1"),
+		)),
+	)))
+	g.Expect(spec.Containers[0].VolumeMounts).To(ContainElements(
+		HaveField("MountPath", "/etc/loki/rules"),
+		And(HaveField("Name", "rules-0"), HaveField("MountPath", "/etc/loki/rules/team-a")),
+		And(HaveField("Name", "rules-1"), HaveField("MountPath", "/etc/loki/rules/team-b")),
+	))
+
+	g.Expect(statefulSets(t, opts)["loki-read"].Spec.Template.Spec.Volumes).NotTo(ContainElement(HaveField("Name", "rules")))
+}
+
+func TestLokiConfigMap_RulesUseLocalStorage(t *testing.T) {
+	g := NewWithT(t)
+
+	cfg := renderConfig(t, ssdlokiv1.SsdLokiSpec{
+		Rules: &ssdlokiv1.RulesSpec{Enabled: true},
+	})
+	g.Expect(cfg["ruler"]).To(And(
+		HaveKeyWithValue("rule_path", "/var/loki/scratch"),
+		HaveKeyWithValue("storage", And(
+			HaveKeyWithValue("type", "local"),
+			HaveKeyWithValue("local", HaveKeyWithValue("directory", "/etc/loki/rules")),
+		)),
+	))
+
+	cfg = renderConfig(t, ssdlokiv1.SsdLokiSpec{
+		Ruler: &ssdlokiv1.RulerConfig{
+			Storage: &ssdlokiv1.RulerStorageConfig{Type: "s3"},
+		},
+	})
+	g.Expect(cfg["ruler"]).To(HaveKeyWithValue("storage", HaveKeyWithValue("type", "s3")))
+}
@@ -5,11 +5,11 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
-func buildLokiMinioSecret() *corev1.Secret {
+func buildLokiMinioSecret(namespace string) *corev1.Secret {
 	return &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      "loki-minio",
-			Namespace: "default",
+			Namespace: namespace,
 			Labels: map[string]string{
 				"app": "minio",
 			},
@@ -6,25 +6,25 @@ import (
 	"k8s.io/utils/ptr"
 )
 
-func buildLokiSA(name string) *corev1.ServiceAccount {
+func buildLokiSA(opts Options) *corev1.ServiceAccount {
 	return &corev1.ServiceAccount{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      name,
-			Namespace: "default",
+			Name:      serviceAccountName(opts.Name),
+			Namespace: opts.Namespace,
 			Labels: map[string]string{
 				"app.kubernetes.io/name":     "loki",
-				"app.kubernetes.io/instance": "loki",
+				"app.kubernetes.io/instance": opts.Name,
 			},
 		},
 		AutomountServiceAccountToken: ptr.To(true),
 	}
 }
 
-func buildMinioSA(name string) *corev1.ServiceAccount {
+func buildMinioSA(name, namespace string) *corev1.ServiceAccount {
 	return &corev1.ServiceAccount{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      name,
-			Namespace: "default",
+			Namespace: namespace,
 		},
 	}
 }
@@ -0,0 +1,88 @@
+package storage
+
+import (
+	"path"
+
+	"github.com/ViaQ/logerr/kverrors"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ConfigureStatefulSet injects the object storage credentials into the Loki
+// container of the StatefulSet and annotates its pod template with the hash
+// of the Secret. It is a no-op when no credentials Secret is configured.
+func ConfigureStatefulSet(sts *appsv1.StatefulSet, opts Options) error {
+	if opts.SecretName == "" {
+		return nil
+	}
+
+	return configurePodSpec(&sts.Spec.Template, opts)
+}
+
+func configurePodSpec(tpl *corev1.PodTemplateSpec, opts Options) error {
+	if len(tpl.Spec.Containers) == 0 {
+		return kverrors.New("pod template has no containers")
+	}
+
+	c := &tpl.Spec.Containers[lokiContainerIndex]
+	switch opts.SharedStore {
+	case ObjectStorageTypeS3:
+		c.Env = append(c.Env,
+			envFromSecret(EnvAWSAccessKeyID, opts.SecretName, KeyAWSAccessKeyID),
+			envFromSecret(EnvAWSAccessKeySecret, opts.SecretName, KeyAWSAccessKeySecret),
+		)
+	case ObjectStorageTypeGCS:
+		// The GCS client reads the service account key from a file, so the
+		// Secret is mounted instead of exposed through the environment.
+		tpl.Spec.Volumes = append(tpl.Spec.Volumes, corev1.Volume{
+			Name: secretVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: opts.SecretName,
+				},
+			},
+		})
+		c.VolumeMounts = append(c.VolumeMounts, corev1.VolumeMount{
+			Name:      secretVolumeName,
+			ReadOnly:  true,
+			MountPath: secretDirectory,
+		})
+		c.Env = append(c.Env, corev1.EnvVar{
+			Name:  EnvGoogleApplicationCredentials,
+			Value: path.Join(secretDirectory, KeyGCPServiceAccountKeyFilename),
+		})
+	case ObjectStorageTypeAzure:
+		c.Env = append(c.Env,
+			envFromSecret(EnvAzureStorageAccountName, opts.SecretName, KeyAzureStorageAccountName),
+			envFromSecret(EnvAzureStorageAccountKey, opts.SecretName, KeyAzureStorageAccountKey),
+		)
+	case ObjectStorageTypeSwift:
+		c.Env = append(c.Env,
+			envFromSecret(EnvSwiftUsername, opts.SecretName, KeySwiftUsername),
+			envFromSecret(EnvSwiftPassword, opts.SecretName, KeySwiftPassword),
+		)
+	default:
+		return kverrors.New("object storage does not support a credentials secret", "type", opts.SharedStore)
+	}
+
+	if tpl.Annotations == nil {
+		tpl.Annotations = map[string]string{}
+	}
+	tpl.Annotations[AnnotationStorageSecretHash] = opts.SecretSHA1
+
+	return nil
+}
+
+func envFromSecret(name, secretName, key string) corev1.EnvVar {
+	return corev1.EnvVar{
+		Name: name,
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: secretName,
+				},
+				Key: key,
+			},
+		},
+	}
+}
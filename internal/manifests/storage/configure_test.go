@@ -0,0 +1,95 @@
+package storage
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func newStatefulSet() *appsv1.StatefulSet {
+	return &appsv1.StatefulSet{
+		Spec: appsv1.StatefulSetSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "loki"}},
+				},
+			},
+		},
+	}
+}
+
+func TestConfigureStatefulSet_NoSecret(t *testing.T) {
+	g := NewWithT(t)
+
+	sts := newStatefulSet()
+	g.Expect(ConfigureStatefulSet(sts, Options{SharedStore: ObjectStorageTypeS3})).To(Succeed())
+	g.Expect(sts).To(Equal(newStatefulSet()))
+}
+
+func TestConfigureStatefulSet_CredentialsPerBackend(t *testing.T) {
+	tt := []struct {
+		storageType ObjectStorageType
+		wantEnv     []string
+		wantVolume  bool
+	}{
+		{
+			storageType: ObjectStorageTypeS3,
+			wantEnv:     []string{EnvAWSAccessKeyID, EnvAWSAccessKeySecret},
+		},
+		{
+			storageType: ObjectStorageTypeGCS,
+			wantEnv:     []string{EnvGoogleApplicationCredentials},
+			wantVolume:  true,
+		},
+		{
+			storageType: ObjectStorageTypeAzure,
+			wantEnv:     []string{EnvAzureStorageAccountName, EnvAzureStorageAccountKey},
+		},
+		{
+			storageType: ObjectStorageTypeSwift,
+			wantEnv:     []string{EnvSwiftUsername, EnvSwiftPassword},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(string(tc.storageType), func(t *testing.T) {
+			g := NewWithT(t)
+
+			sts := newStatefulSet()
+			opts := Options{
+				SharedStore: tc.storageType,
+				SecretName:  "creds",
+				SecretSHA1:  "deadbeef",
+			}
+			g.Expect(ConfigureStatefulSet(sts, opts)).To(Succeed())
+
+			c := sts.Spec.Template.Spec.Containers[0]
+			var env []string
+			for _, e := range c.Env {
+				env = append(env, e.Name)
+			}
+			g.Expect(env).To(ConsistOf(tc.wantEnv))
+			g.Expect(sts.Spec.Template.Annotations).To(HaveKeyWithValue(AnnotationStorageSecretHash, "deadbeef"))
+
+			if tc.wantVolume {
+				g.Expect(sts.Spec.Template.Spec.Volumes).To(HaveLen(1))
+				g.Expect(sts.Spec.Template.Spec.Volumes[0].Secret.SecretName).To(Equal("creds"))
+				g.Expect(c.VolumeMounts).To(HaveLen(1))
+			} else {
+				g.Expect(sts.Spec.Template.Spec.Volumes).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestConfigureStatefulSet_FilesystemRejectsSecret(t *testing.T) {
+	g := NewWithT(t)
+
+	opts := Options{
+		SharedStore: ObjectStorageTypeFilesystem,
+		SecretName:  "creds",
+	}
+	g.Expect(ConfigureStatefulSet(newStatefulSet(), opts)).NotTo(Succeed())
+}
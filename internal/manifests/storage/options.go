@@ -0,0 +1,31 @@
+package storage
+
+// ObjectStorageType is the name of an object storage backend as used in the
+// Loki schema config.
+type ObjectStorageType string
+
+const (
+	// ObjectStorageTypeS3 is AWS S3 or an S3 compatible store.
+	ObjectStorageTypeS3 ObjectStorageType = "s3"
+	// ObjectStorageTypeGCS is Google Cloud Storage.
+	ObjectStorageTypeGCS ObjectStorageType = "gcs"
+	// ObjectStorageTypeAzure is Azure Blob Storage.
+	ObjectStorageTypeAzure ObjectStorageType = "azure"
+	// ObjectStorageTypeSwift is OpenStack Swift.
+	ObjectStorageTypeSwift ObjectStorageType = "swift"
+	// ObjectStorageTypeFilesystem is the local filesystem of each pod.
+	ObjectStorageTypeFilesystem ObjectStorageType = "filesystem"
+)
+
+// Options is used to configure Loki to integrate with the object storage
+// credentials referenced by the SsdLoki.
+type Options struct {
+	// SharedStore is the backend configured in the stack spec.
+	SharedStore ObjectStorageType
+	// SecretName is the name of the Secret holding the credentials. It is empty
+	// when the stack uses no credentials Secret.
+	SecretName string
+	// SecretSHA1 is the hash of the Secret data, used to roll out the pods
+	// whenever the credentials change.
+	SecretSHA1 string
+}
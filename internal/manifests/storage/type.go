@@ -0,0 +1,63 @@
+package storage
+
+import (
+	"github.com/ViaQ/logerr/kverrors"
+
+	ssdlokiv1 "github.com/ssd-loki/loki-operator/api/v1"
+)
+
+// TypeOf returns the backend selected in the common storage section. The
+// filesystem is used when no backend is set. An error is returned when more
+// than one backend is set.
+func TypeOf(s *ssdlokiv1.CommonStorage) (ObjectStorageType, error) {
+	if s == nil {
+		return ObjectStorageTypeFilesystem, nil
+	}
+
+	var types []ObjectStorageType
+	if s.S3 != nil {
+		types = append(types, ObjectStorageTypeS3)
+	}
+	if s.GCS != nil {
+		types = append(types, ObjectStorageTypeGCS)
+	}
+	if s.Azure != nil {
+		types = append(types, ObjectStorageTypeAzure)
+	}
+	if s.Swift != nil {
+		types = append(types, ObjectStorageTypeSwift)
+	}
+	if s.Filesystem != nil {
+		types = append(types, ObjectStorageTypeFilesystem)
+	}
+
+	switch len(types) {
+	case 0:
+		return ObjectStorageTypeFilesystem, nil
+	case 1:
+		return types[0], nil
+	default:
+		return "", kverrors.New("only one object storage backend may be set", "backends", types)
+	}
+}
+
+// SecretKeys returns the keys the credentials Secret of the backend must contain.
+func SecretKeys(t ObjectStorageType) []string {
+	switch t {
+	case ObjectStorageTypeS3:
+		return []string{KeyAWSAccessKeyID, KeyAWSAccessKeySecret}
+	case ObjectStorageTypeGCS:
+		return []string{KeyGCPServiceAccountKeyFilename}
+	case ObjectStorageTypeAzure:
+		return []string{KeyAzureStorageAccountName, KeyAzureStorageAccountKey}
+	case ObjectStorageTypeSwift:
+		return []string{KeySwiftUsername, KeySwiftPassword}
+	default:
+		return nil
+	}
+}
+
+// SecretRequired returns true if the backend cannot be used without a credentials Secret.
+func SecretRequired(t ObjectStorageType) bool {
+	return t == ObjectStorageTypeAzure || t == ObjectStorageTypeSwift
+}
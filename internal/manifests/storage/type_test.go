@@ -0,0 +1,55 @@
+package storage
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	ssdlokiv1 "github.com/ssd-loki/loki-operator/api/v1"
+)
+
+func TestTypeOf(t *testing.T) {
+	tt := []struct {
+		desc    string
+		storage *ssdlokiv1.CommonStorage
+		want    ObjectStorageType
+		wantErr bool
+	}{
+		{
+			desc: "no storage",
+			want: ObjectStorageTypeFilesystem,
+		},
+		{
+			desc:    "no backend",
+			storage: &ssdlokiv1.CommonStorage{},
+			want:    ObjectStorageTypeFilesystem,
+		},
+		{
+			desc:    "gcs",
+			storage: &ssdlokiv1.CommonStorage{GCS: &ssdlokiv1.GCSConfig{}},
+			want:    ObjectStorageTypeGCS,
+		},
+		{
+			desc: "several backends",
+			storage: &ssdlokiv1.CommonStorage{
+				Azure: &ssdlokiv1.AzureConfig{},
+				Swift: &ssdlokiv1.SwiftConfig{},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.desc, func(t *testing.T) {
+			g := NewWithT(t)
+
+			got, err := TypeOf(tc.storage)
+			if tc.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(got).To(Equal(tc.want))
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package storage
+
+const (
+	// KeyAWSAccessKeyID is the secret data key for the AWS access key id.
+	KeyAWSAccessKeyID = "access_key_id"
+	// KeyAWSAccessKeySecret is the secret data key for the AWS secret access key.
+	KeyAWSAccessKeySecret = "access_key_secret"
+	// KeyGCPServiceAccountKeyFilename is the secret data key for the GCP service account key file.
+	KeyGCPServiceAccountKeyFilename = "key.json"
+	// KeyAzureStorageAccountName is the secret data key for the Azure storage account name.
+	KeyAzureStorageAccountName = "account_name"
+	// KeyAzureStorageAccountKey is the secret data key for the Azure storage account key.
+	KeyAzureStorageAccountKey = "account_key"
+	// KeySwiftUsername is the secret data key for the Swift user name.
+	KeySwiftUsername = "username"
+	// KeySwiftPassword is the secret data key for the Swift password.
+	KeySwiftPassword = "password"
+
+	// EnvAWSAccessKeyID is the environment variable holding the AWS access key id.
+	EnvAWSAccessKeyID = "AWS_ACCESS_KEY_ID"
+	// EnvAWSAccessKeySecret is the environment variable holding the AWS secret access key.
+	EnvAWSAccessKeySecret = "AWS_SECRET_ACCESS_KEY"
+	// EnvGoogleApplicationCredentials is the environment variable pointing at the GCP key file.
+	EnvGoogleApplicationCredentials = "GOOGLE_APPLICATION_CREDENTIALS"
+	// EnvAzureStorageAccountName is the environment variable holding the Azure storage account name.
+	EnvAzureStorageAccountName = "AZURE_STORAGE_ACCOUNT_NAME"
+	// EnvAzureStorageAccountKey is the environment variable holding the Azure storage account key.
+	EnvAzureStorageAccountKey = "AZURE_STORAGE_ACCOUNT_KEY"
+	// EnvSwiftUsername is the environment variable holding the Swift user name.
+	EnvSwiftUsername = "SWIFT_USERNAME"
+	// EnvSwiftPassword is the environment variable holding the Swift password.
+	EnvSwiftPassword = "SWIFT_PASSWORD"
+
+	// AnnotationStorageSecretHash is the pod annotation holding the hash of the storage secret.
+	AnnotationStorageSecretHash = "ssd-loki.ssd-loki.com/storage-secret-hash"
+
+	secretVolumeName   = "storage-secret"
+	secretDirectory    = "/etc/storage/secrets"
+	lokiContainerIndex = 0
+)
@@ -0,0 +1,213 @@
+package manifests
+
+import (
+	"path"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	ssdlokiv1 "github.com/ssd-loki/loki-operator/api/v1"
+	"github.com/ssd-loki/loki-operator/internal/manifests/internal/config"
+)
+
+const (
+	tlsCertificatesVolumeName = "tls-certificates"
+	tlsCertificatesMountDir   = "/var/run/tls/server"
+	tlsCAVolumeName           = "tls-ca"
+	tlsCAMountDir             = "/var/run/tls/ca"
+
+	defaultCAKey = "service-ca.crt"
+)
+
+// tlsProfiles maps the TLS profiles of the spec to cipher suites and minimum
+// TLS versions in the form understood by Loki and the gateway. TLS 1.3 cipher
+// suites cannot be configured in Go, so the Modern profile lists none.
+var tlsProfiles = map[ssdlokiv1.TLSProfileType]TLSProfileSpec{
+	ssdlokiv1.TLSProfileOld: {
+		Ciphers: []string{
+			"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256",
+			"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256",
+			"TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384",
+			"TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384",
+			"TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305_SHA256",
+			"TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256",
+			"TLS_ECDHE_ECDSA_WITH_AES_128_CBC_SHA256",
+			"TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA256",
+			"TLS_ECDHE_ECDSA_WITH_AES_128_CBC_SHA",
+			"TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA",
+			"TLS_ECDHE_ECDSA_WITH_AES_256_CBC_SHA",
+			"TLS_ECDHE_RSA_WITH_AES_256_CBC_SHA",
+			"TLS_RSA_WITH_AES_128_GCM_SHA256",
+			"TLS_RSA_WITH_AES_256_GCM_SHA384",
+			"TLS_RSA_WITH_AES_128_CBC_SHA256",
+			"TLS_RSA_WITH_AES_128_CBC_SHA",
+			"TLS_RSA_WITH_AES_256_CBC_SHA",
+			"TLS_RSA_WITH_3DES_EDE_CBC_SHA",
+		},
+		MinTLSVersion: "VersionTLS10",
+	},
+	ssdlokiv1.TLSProfileIntermediate: {
+		Ciphers: []string{
+			"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256",
+			"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256",
+			"TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384",
+			"TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384",
+			"TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305_SHA256",
+			"TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256",
+		},
+		MinTLSVersion: "VersionTLS12",
+	},
+	ssdlokiv1.TLSProfileModern: {
+		MinTLSVersion: "VersionTLS13",
+	},
+}
+
+// tlsProfile returns the TLS profile selected in the spec, which defaults to Intermediate.
+func tlsProfile(stack ssdlokiv1.SsdLoki) TLSProfileSpec {
+	t := ssdlokiv1.TLSProfileIntermediate
+	if stack.Spec.TLS != nil && stack.Spec.TLS.Profile != "" {
+		t = stack.Spec.TLS.Profile
+	}
+	p := tlsProfiles[t]
+	return TLSProfileSpec{
+		Ciphers:       append([]string(nil), p.Ciphers...),
+		MinTLSVersion: p.MinTLSVersion,
+	}
+}
+
+// InternalTLSEnabled reports whether the traffic between the Loki components of
+// the stack is encrypted.
+func InternalTLSEnabled(stack *ssdlokiv1.SsdLoki) bool {
+	return stack.Spec.TLS != nil && stack.Spec.TLS.Enabled
+}
+
+// CAKey returns the key of the CA bundle in the ConfigMap referenced by ca.
+func CAKey(ca *ssdlokiv1.CASpec) string {
+	if ca.CAKey == "" {
+		return defaultCAKey
+	}
+	return ca.CAKey
+}
+
+// CertificateSecretName returns the name of the certificate Secret of a tier of
+// the stack, or an empty string if none is configured.
+func CertificateSecretName(stack *ssdlokiv1.SsdLoki, component string) string {
+	if stack.Spec.TLS == nil || stack.Spec.TLS.Certificates == nil {
+		return ""
+	}
+	certs := stack.Spec.TLS.Certificates
+	switch component {
+	case ComponentRead:
+		return certs.Read
+	case ComponentWrite:
+		return certs.Write
+	case ComponentBackend:
+		return certs.Backend
+	}
+	return ""
+}
+
+// WriteServerName is the name the certificate of the write tier is verified against.
+func WriteServerName(stack *ssdlokiv1.SsdLoki) string {
+	return fqdn(WriteName(stack.Name), stack.Namespace)
+}
+
+// BackendServerName is the name the certificate of the backend tier is verified against.
+func BackendServerName(stack *ssdlokiv1.SsdLoki) string {
+	return fqdn(BackendName(stack.Name), stack.Namespace)
+}
+
+// internalCAPath returns the path the CA bundle of internal TLS is mounted at.
+func internalCAPath(opts Options) string {
+	return path.Join(tlsCAMountDir, CAKey(opts.Stack.Spec.TLS.CA))
+}
+
+// tlsConfigOptions returns the TLS options of the Loki config. Every tier mounts
+// its certificate at the same path, so the config is shared by all tiers.
+func tlsConfigOptions(opts Options) config.TLSOptions {
+	if !InternalTLSEnabled(&opts.Stack) {
+		return config.TLSOptions{}
+	}
+
+	cert := config.TLSCertPath{
+		Certificate: path.Join(tlsCertificatesMountDir, corev1.TLSCertKey),
+		Key:         path.Join(tlsCertificatesMountDir, corev1.TLSPrivateKeyKey),
+	}
+	backend := fqdn(BackendName(opts.Name), opts.Namespace)
+	memberlist := fqdn(memberListName(opts.Name), opts.Namespace)
+
+	return config.TLSOptions{
+		Enabled:       true,
+		Ciphers:       opts.TLSProfile.Ciphers,
+		MinTLSVersion: opts.TLSProfile.MinTLSVersion,
+		Paths: config.TLSFilePaths{
+			CA:   internalCAPath(opts),
+			GRPC: cert,
+			HTTP: cert,
+		},
+		ServerNames: config.TLSServerNames{
+			GRPC: config.GRPCServerNames{
+				Compactor:    backend,
+				IndexGateway: backend,
+				Ingester:     fqdn(WriteName(opts.Name), opts.Namespace),
+				// Queriers return results to the frontends of the read tier and
+				// pull queries from the schedulers of the backend tier with the
+				// same client, so they verify the name shared by all certificates.
+				QueryFrontend:  memberlist,
+				QueryScheduler: backend,
+				Ruler:          backend,
+			},
+			HTTP: config.HTTPServerNames{
+				Querier: fqdn(ReadName(opts.Name), opts.Namespace),
+			},
+			Memberlist: memberlist,
+		},
+	}
+}
+
+// configureInternalTLS mounts the certificate of a tier and the CA bundle into
+// its pods and switches the readiness probe to HTTPS.
+func configureInternalTLS(sts *appsv1.StatefulSet, opts Options, component string) {
+	if !InternalTLSEnabled(&opts.Stack) {
+		return
+	}
+
+	spec := &sts.Spec.Template.Spec
+	spec.Volumes = append(spec.Volumes,
+		corev1.Volume{
+			Name: tlsCertificatesVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: CertificateSecretName(&opts.Stack, component),
+				},
+			},
+		},
+		corev1.Volume{
+			Name: tlsCAVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: opts.Stack.Spec.TLS.CA.CA,
+					},
+				},
+			},
+		},
+	)
+
+	c := &spec.Containers[0]
+	c.VolumeMounts = append(c.VolumeMounts,
+		corev1.VolumeMount{
+			Name:      tlsCertificatesVolumeName,
+			ReadOnly:  true,
+			MountPath: tlsCertificatesMountDir,
+		},
+		corev1.VolumeMount{
+			Name:      tlsCAVolumeName,
+			ReadOnly:  true,
+			MountPath: tlsCAMountDir,
+		},
+	)
+	if p := c.ReadinessProbe; p != nil && p.HTTPGet != nil {
+		p.HTTPGet.Scheme = corev1.URISchemeHTTPS
+	}
+}
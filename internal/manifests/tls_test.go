@@ -0,0 +1,136 @@
+package manifests
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+
+	ssdlokiv1 "github.com/ssd-loki/loki-operator/api/v1"
+	"github.com/ssd-loki/loki-operator/internal/manifests/internal/config"
+)
+
+func internalTLSSpec(profile ssdlokiv1.TLSProfileType) func(*ssdlokiv1.SsdLokiSpec) {
+	return func(spec *ssdlokiv1.SsdLokiSpec) {
+		spec.TLS = &ssdlokiv1.InternalTLSSpec{
+			Enabled: true,
+			CA:      &ssdlokiv1.CASpec{CA: "loki-ca", CAKey: "ca.crt"},
+			Certificates: &ssdlokiv1.ComponentCertificatesSpec{
+				Read:    "loki-read-tls",
+				Write:   "loki-write-tls",
+				Backend: "loki-backend-tls",
+			},
+			Profile: profile,
+		}
+	}
+}
+
+func TestLokiConfigMap_InternalTLS(t *testing.T) {
+	g := NewWithT(t)
+
+	cm, err := LokiConfigMap(newOptions(t, internalTLSSpec("")))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	cfg := map[string]interface{}{}
+	g.Expect(yaml.Unmarshal([]byte(cm.Data[config.LokiConfigFileName]), &cfg)).To(Succeed())
+
+	server := cfg["server"].(map[string]interface{})
+	g.Expect(server).To(HaveKeyWithValue("http_tls_config", And(
+		HaveKeyWithValue("cert_file", "/var/run/tls/server/tls.crt"),
+		HaveKeyWithValue("key_file", "/var/run/tls/server/tls.key"),
+		HaveKeyWithValue("client_ca_file", "/var/run/tls/ca/ca.crt"),
+	)))
+	g.Expect(server).To(HaveKeyWithValue("grpc_tls_config", HaveKeyWithValue("client_auth_type", "RequireAndVerifyClientCert")))
+	g.Expect(server).To(HaveKeyWithValue("tls_min_version", "VersionTLS12"))
+	g.Expect(server).To(HaveKeyWithValue("tls_cipher_suites", ContainSubstring("TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256")))
+
+	g.Expect(cfg["memberlist"]).To(And(
+		HaveKeyWithValue("tls_enabled", true),
+		HaveKeyWithValue("tls_server_name", "loki-memberlist.ns.svc.cluster.local"),
+	))
+	g.Expect(cfg["ingester_client"]).To(HaveKeyWithValue("grpc_client_config", And(
+		HaveKeyWithValue("tls_enabled", true),
+		HaveKeyWithValue("tls_server_name", "loki-write.ns.svc.cluster.local"),
+	)))
+	g.Expect(cfg["compactor_grpc_client"]).To(HaveKeyWithValue("tls_server_name", "loki-backend.ns.svc.cluster.local"))
+	g.Expect(cfg["frontend"]).To(HaveKeyWithValue("grpc_client_config", HaveKeyWithValue("tls_enabled", true)))
+	g.Expect(cfg["frontend_worker"]).To(HaveKeyWithValue("grpc_client_config", HaveKeyWithValue("tls_enabled", true)))
+
+	tsdb := cfg["storage_config"].(map[string]interface{})["tsdb_shipper"]
+	g.Expect(tsdb).To(HaveKeyWithValue("index_gateway_client", HaveKeyWithValue("grpc_client_config", And(
+		HaveKeyWithValue("tls_enabled", true),
+		HaveKeyWithValue("tls_ca_path", "/var/run/tls/ca/ca.crt"),
+	))))
+	g.Expect(cfg["common"]).To(HaveKeyWithValue("compactor_address", "https://loki-backend.ns.svc.cluster.local:3100"))
+}
+
+func TestLokiConfigMap_InternalTLSDisabled(t *testing.T) {
+	g := NewWithT(t)
+
+	cfg := renderConfig(t, ssdlokiv1.SsdLokiSpec{})
+
+	g.Expect(cfg["server"]).NotTo(HaveKey("http_tls_config"))
+	g.Expect(cfg["memberlist"]).NotTo(HaveKey("tls_enabled"))
+	g.Expect(cfg).NotTo(HaveKey("ingester_client"))
+	g.Expect(cfg).NotTo(HaveKey("frontend"))
+}
+
+func TestLokiConfigMap_ModernProfileHasNoCipherSuites(t *testing.T) {
+	g := NewWithT(t)
+
+	cm, err := LokiConfigMap(newOptions(t, internalTLSSpec(ssdlokiv1.TLSProfileModern)))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	cfg := map[string]interface{}{}
+	g.Expect(yaml.Unmarshal([]byte(cm.Data[config.LokiConfigFileName]), &cfg)).To(Succeed())
+	g.Expect(cfg["server"]).To(HaveKeyWithValue("tls_min_version", "VersionTLS13"))
+	g.Expect(cfg["server"]).NotTo(HaveKey("tls_cipher_suites"))
+}
+
+func TestBuildAll_InternalTLSMountsCertificates(t *testing.T) {
+	g := NewWithT(t)
+
+	sts := statefulSets(t, newOptions(t, internalTLSSpec("")))
+
+	for name, secret := range map[string]string{
+		"loki-read":    "loki-read-tls",
+		"loki-write":   "loki-write-tls",
+		"loki-backend": "loki-backend-tls",
+	} {
+		spec := sts[name].Spec.Template.Spec
+		g.Expect(spec.Volumes).To(ContainElement(And(
+			HaveField("Name", tlsCertificatesVolumeName),
+			HaveField("VolumeSource.Secret.SecretName", secret),
+		)), name)
+		g.Expect(spec.Volumes).To(ContainElement(HaveField("VolumeSource.ConfigMap.Name", "loki-ca")), name)
+
+		c := spec.Containers[0]
+		g.Expect(c.VolumeMounts).To(ContainElement(HaveField("MountPath", tlsCertificatesMountDir)), name)
+		g.Expect(c.ReadinessProbe.HTTPGet.Scheme).To(Equal(corev1.URISchemeHTTPS), name)
+	}
+}
+
+func TestBuildGateway_InternalTLSUpstreams(t *testing.T) {
+	g := NewWithT(t)
+
+	opts := gatewayOptionsFor(t)
+	opts.Stack.Spec.TLS = newOptions(t, internalTLSSpec("")).Stack.Spec.TLS
+
+	objs, err := BuildGateway(opts)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	var dpl *appsv1.Deployment
+	for _, obj := range objs {
+		if d, ok := obj.(*appsv1.Deployment); ok {
+			dpl = d
+		}
+	}
+	g.Expect(dpl.Spec.Template.Spec.Containers[0].Args).To(ContainElements(
+		"--logs.read.endpoint=https://loki-read.ns.svc.cluster.local:3100",
+		"--logs.write.endpoint=https://loki-write.ns.svc.cluster.local:3100",
+		"--logs.tls.ca-file=/var/run/tls/ca/ca.crt",
+		"--tls.min-version=VersionTLS12",
+	))
+}
@@ -2,10 +2,30 @@ package manifests
 
 import (
 	"fmt"
+	"net"
+	"strconv"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
+
+	ssdlokiv1 "github.com/ssd-loki/loki-operator/api/v1"
+)
+
+// Values of the app.kubernetes.io/component label of the pods of a stack.
+const (
+	ComponentRead      = "read"
+	ComponentWrite     = "write"
+	ComponentBackend   = "backend"
+	ComponentGateway   = "gateway"
+	ComponentMemcached = "memcached"
+	ComponentCanary    = "canary"
 )
 
+// ComponentLabels returns the labels selecting the pods of a component of a stack.
+func ComponentLabels(stackName, component string) map[string]string {
+	return commonLabels(stackName, component)
+}
+
 func commonLabels(instanceName string, component string) map[string]string {
 	return map[string]string{
 		"app.kubernetes.io/name":      "loki",
@@ -14,9 +34,10 @@ func commonLabels(instanceName string, component string) map[string]string {
 	}
 }
 
-func memberListLabels() map[string]string {
+func memberListLabels(instanceName string) map[string]string {
 	return map[string]string{
-		"app.kubernetes.io/part-of": "memberlist",
+		"app.kubernetes.io/instance": instanceName,
+		"app.kubernetes.io/part-of":  "memberlist",
 	}
 }
 
@@ -27,10 +48,92 @@ func headlessServiceLabels() map[string]string {
 	}
 }
 
+// ReadName is the name of the read tier StatefulSet, Service and PodDisruptionBudget of a stack.
+func ReadName(stackName string) string {
+	return fmt.Sprintf("%s-read", stackName)
+}
+
+// WriteName is the name of the write tier StatefulSet, Service and PodDisruptionBudget of a stack.
+func WriteName(stackName string) string {
+	return fmt.Sprintf("%s-write", stackName)
+}
+
+// BackendName is the name of the backend tier StatefulSet, Service and PodDisruptionBudget of a stack.
+func BackendName(stackName string) string {
+	return fmt.Sprintf("%s-backend", stackName)
+}
+
+func headlessServiceName(componentName string) string {
+	return fmt.Sprintf("%s-headless", componentName)
+}
+
+func memberListName(stackName string) string {
+	return fmt.Sprintf("%s-memberlist", stackName)
+}
+
+func configMapName(stackName string) string {
+	return stackName
+}
+
+func runtimeConfigMapName(stackName string) string {
+	return fmt.Sprintf("%s-runtime", stackName)
+}
+
+func serviceAccountName(stackName string) string {
+	return stackName
+}
+
+// podAnnotations returns the annotations of the pod templates of the read, write
+// and backend tiers.
+func podAnnotations(opts Options) map[string]string {
+	if opts.ConfigSHA1 == "" {
+		return nil
+	}
+	return map[string]string{
+		AnnotationLokiConfigHash: opts.ConfigSHA1,
+	}
+}
+
 func fqdn(serviceName, namespace string) string {
 	return fmt.Sprintf("%s.%s.svc.cluster.local", serviceName, namespace)
 }
 
+// envPlaceholder returns a reference to an environment variable of the Loki
+// container, expanded by Loki on startup with -config.expand-env. Credentials
+// read from Secrets are passed this way, as the Loki config is a ConfigMap.
+func envPlaceholder(name string) string {
+	return fmt.Sprintf("${%s}", name)
+}
+
+// IngesterRingURL returns the URL of the ingester ring page served by the write
+// tier of a stack.
+func IngesterRingURL(stack *ssdlokiv1.SsdLoki) string {
+	return fmt.Sprintf("%s://%s:%d/ring", httpScheme(stack), fqdn(WriteName(stack.Name), stack.Namespace), LokiHTTPPort(stack))
+}
+
+// CompactorDeleteURL returns the URL of the log deletion API served by the
+// compactor of the backend tier of a stack.
+func CompactorDeleteURL(stack *ssdlokiv1.SsdLoki) string {
+	return fmt.Sprintf("%s://%s:%d/loki/api/v1/delete", httpScheme(stack), fqdn(BackendName(stack.Name), stack.Namespace), LokiHTTPPort(stack))
+}
+
+// PodReadyURL returns the URL of the readiness endpoint of a single Loki pod.
+func PodReadyURL(stack *ssdlokiv1.SsdLoki, podIP string) string {
+	return fmt.Sprintf("%s://%s/ready", httpScheme(stack), net.JoinHostPort(podIP, strconv.Itoa(int(LokiHTTPPort(stack)))))
+}
+
+// httpScheme returns the scheme of the HTTP endpoints of the Loki components.
+func httpScheme(stack *ssdlokiv1.SsdLoki) string {
+	if InternalTLSEnabled(stack) {
+		return "https"
+	}
+	return "http"
+}
+
+// AnnotationLokiConfigHash is the pod annotation holding the hash of the Loki config
+// and referenced Secrets. Changing it rolls the pods of a tier.
+const AnnotationLokiConfigHash = "ssd-loki.ssd-loki.com/config-hash"
+
 const (
 	lokiHTTPPortName       = "http-metrics"
 	lokiGRPCPortName       = "grpc"
@@ -41,5 +144,18 @@ const (
 	protocolTCP            = corev1.ProtocolTCP
 	HeadLessClusterIP      = "None"
 	defaultImage           = "docker.io/grafana/loki:3.1.1"
-	defaultnamespace       = "default"
+
+	// defaultQuerierMaxConcurrent is the querier concurrency used when the read tier requests no CPU.
+	defaultQuerierMaxConcurrent = 4
+)
+
+const (
+	// lokiDefaultQueryTimeout contains the default query timeout. It should match the value mentioned in the CRD
+	// and the default from the Loki configuration.
+	lokiDefaultQueryTimeout    = 3 * time.Minute
+	lokiDefaultHTTPIdleTimeout = 30 * time.Second
+	lokiQueryWriteDuration     = 1 * time.Minute
+
+	gatewayReadDuration  = 30 * time.Second
+	gatewayWriteDuration = 2 * time.Minute
 )
@@ -9,10 +9,19 @@ import (
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/ssd-loki/loki-operator/internal/manifests/storage"
 )
 
 func BuildWrite(opts Options) ([]client.Object, error) {
 	statefulset := NewWriteStatefulSet(opts)
+	if err := storage.ConfigureStatefulSet(statefulset, opts.ObjectStorage); err != nil {
+		return nil, err
+	}
+	configureZoneAwareness(statefulset, opts)
+	configureInternalTLS(statefulset, opts, ComponentWrite)
+	configurePodTemplate(statefulset, opts.Stack.Spec.Template.Write)
+
 	objs := []client.Object{
 		statefulset,
 		NewLokiWriteService(opts),
@@ -24,26 +33,27 @@ func BuildWrite(opts Options) ([]client.Object, error) {
 }
 
 func NewWriteStatefulSet(opts Options) *appsv1.StatefulSet {
-	writeLabels := commonLabels("write", opts.Name)
+	writeLabels := commonLabels(opts.Name, ComponentWrite)
 
 	// 컨테이너 정의
 	container := corev1.Container{
 		Name:            "loki",
-		Image:           defaultImage,
+		Image:           opts.Image,
 		ImagePullPolicy: corev1.PullIfNotPresent,
 		Args: []string{
 			"-config.file=/etc/loki/config/config.yaml", //TODO
+			"-config.expand-env=true",
 			"-target=write",
 		},
 		Ports: []corev1.ContainerPort{
 			{
 				Name:          lokiHTTPPortName,
-				ContainerPort: httpPort,
+				ContainerPort: opts.lokiHTTPPort(),
 				Protocol:      protocolTCP,
 			},
 			{
 				Name:          lokiGRPCPortName,
-				ContainerPort: grpcPort,
+				ContainerPort: opts.lokiGRPCPort(),
 				Protocol:      protocolTCP,
 			},
 			{
@@ -52,6 +62,10 @@ func NewWriteStatefulSet(opts Options) *appsv1.StatefulSet {
 				Protocol:      protocolTCP,
 			},
 		},
+		Resources: corev1.ResourceRequirements{
+			Limits:   opts.ResourceRequirements.Write.Limits,
+			Requests: opts.ResourceRequirements.Write.Requests,
+		},
 		SecurityContext: &corev1.SecurityContext{
 			AllowPrivilegeEscalation: ptr.To(false),
 			Capabilities: &corev1.Capabilities{
@@ -63,7 +77,7 @@ func NewWriteStatefulSet(opts Options) *appsv1.StatefulSet {
 			ProbeHandler: corev1.ProbeHandler{
 				HTTPGet: &corev1.HTTPGetAction{
 					Path: "/ready",
-					Port: intstr.IntOrString{IntVal: httpPort},
+					Port: intstr.FromInt32(opts.lokiHTTPPort()),
 				},
 			},
 			InitialDelaySeconds: 30,
@@ -87,7 +101,7 @@ func NewWriteStatefulSet(opts Options) *appsv1.StatefulSet {
 
 	// PodSpec 정의
 	podSpec := corev1.PodSpec{
-		ServiceAccountName:            "loki",
+		ServiceAccountName:            serviceAccountName(opts.Name),
 		AutomountServiceAccountToken:  ptr.To(true),
 		EnableServiceLinks:            ptr.To(true),
 		TerminationGracePeriodSeconds: ptr.To(int64(300)),
@@ -97,15 +111,14 @@ func NewWriteStatefulSet(opts Options) *appsv1.StatefulSet {
 			RunAsNonRoot: ptr.To(true),
 			RunAsUser:    ptr.To(int64(10001)),
 		},
-		Containers: []corev1.Container{container},
+		Containers:                []corev1.Container{container},
+		TopologySpreadConstraints: topologySpreadConstraints(opts, ComponentWrite),
 		Affinity: &corev1.Affinity{
 			PodAntiAffinity: &corev1.PodAntiAffinity{
 				RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{
 					{
 						LabelSelector: &metav1.LabelSelector{
-							MatchLabels: map[string]string{
-								"app.kubernetes.io/component": "write",
-							},
+							MatchLabels: writeLabels,
 						},
 						TopologyKey: "kubernetes.io/hostname",
 					},
@@ -118,7 +131,7 @@ func NewWriteStatefulSet(opts Options) *appsv1.StatefulSet {
 				VolumeSource: corev1.VolumeSource{
 					ConfigMap: &corev1.ConfigMapVolumeSource{
 						LocalObjectReference: corev1.LocalObjectReference{
-							Name: "loki",
+							Name: configMapName(opts.Name),
 						},
 						Items: []corev1.KeyToPath{
 							{
@@ -134,7 +147,7 @@ func NewWriteStatefulSet(opts Options) *appsv1.StatefulSet {
 				VolumeSource: corev1.VolumeSource{
 					ConfigMap: &corev1.ConfigMapVolumeSource{
 						LocalObjectReference: corev1.LocalObjectReference{
-							Name: "loki-runtime",
+							Name: runtimeConfigMapName(opts.Name),
 						},
 					},
 				},
@@ -155,19 +168,20 @@ func NewWriteStatefulSet(opts Options) *appsv1.StatefulSet {
 			APIVersion: appsv1.SchemeGroupVersion.String(),
 		},
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      "loki-write",
-			Namespace: "default",
-			Labels:    labels.Merge(memberListLabels(), writeLabels),
+			Name:      WriteName(opts.Name),
+			Namespace: opts.Namespace,
+			Labels:    labels.Merge(memberListLabels(opts.Name), writeLabels),
 		},
 		Spec: appsv1.StatefulSetSpec{
-			Replicas: ptr.To(int32(3)),
+			Replicas: ptr.To(*opts.Stack.Spec.Template.Write.Replicas),
 			Selector: &metav1.LabelSelector{
 				MatchLabels: writeLabels,
 			},
-			ServiceName: "loki-write-headless",
+			ServiceName: headlessServiceName(WriteName(opts.Name)),
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels: labels.Merge(memberListLabels(), writeLabels),
+					Labels:      labels.Merge(memberListLabels(opts.Name), writeLabels),
+					Annotations: podAnnotations(opts),
 				},
 				Spec: podSpec,
 			},
@@ -183,9 +197,10 @@ func NewWriteStatefulSet(opts Options) *appsv1.StatefulSet {
 						AccessModes: []corev1.PersistentVolumeAccessMode{
 							corev1.ReadWriteOnce,
 						},
+						StorageClassName: storageClassName(opts.Stack.Spec.Template.Write),
 						Resources: corev1.VolumeResourceRequirements{
 							Requests: corev1.ResourceList{
-								corev1.ResourceStorage: opts.ResourceRequirements.Read.PVCSize,
+								corev1.ResourceStorage: opts.ResourceRequirements.Write.PVCSize,
 							},
 						},
 					},
@@ -201,8 +216,8 @@ func NewWriteStatefulSet(opts Options) *appsv1.StatefulSet {
 }
 
 func NewLokiWriteService(opts Options) *corev1.Service {
-	serviceName := "loki-write"
-	writeLabels := commonLabels(opts.Name, "write")
+	serviceName := WriteName(opts.Name)
+	writeLabels := commonLabels(opts.Name, ComponentWrite)
 
 	// Return the new service object
 	return &corev1.Service{
@@ -212,7 +227,7 @@ func NewLokiWriteService(opts Options) *corev1.Service {
 		},
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      serviceName,
-			Namespace: "default",
+			Namespace: opts.Namespace,
 			Labels:    writeLabels,
 		},
 		Spec: corev1.ServiceSpec{
@@ -220,15 +235,15 @@ func NewLokiWriteService(opts Options) *corev1.Service {
 			Ports: []corev1.ServicePort{
 				{
 					Name:       lokiHTTPPortName,
-					Port:       httpPort,
+					Port:       opts.lokiHTTPPort(),
 					Protocol:   protocolTCP,
-					TargetPort: intstr.IntOrString{StrVal: lokiHTTPPortName},
+					TargetPort: intstr.FromString(lokiHTTPPortName),
 				},
 				{
 					Name:       lokiGRPCPortName,
-					Port:       grpcPort,
+					Port:       opts.lokiGRPCPort(),
 					Protocol:   protocolTCP,
-					TargetPort: intstr.IntOrString{StrVal: lokiGRPCPortName},
+					TargetPort: intstr.FromString(lokiGRPCPortName),
 				},
 			},
 			Selector: writeLabels,
@@ -236,10 +251,10 @@ func NewLokiWriteService(opts Options) *corev1.Service {
 	}
 }
 
-// NewLokiWriteService creates a k8s service for the Loki write component
+// NewLokiWriteHeadlessService creates a headless k8s service for the Loki write component
 func NewLokiWriteHeadlessService(opts Options) *corev1.Service {
-	serviceName := "loki-write"
-	writeLabels := commonLabels(opts.Name, "write")
+	serviceName := headlessServiceName(WriteName(opts.Name))
+	writeLabels := commonLabels(opts.Name, ComponentWrite)
 	// Return the new service object
 	return &corev1.Service{
 		TypeMeta: metav1.TypeMeta{
@@ -248,7 +263,7 @@ func NewLokiWriteHeadlessService(opts Options) *corev1.Service {
 		},
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      serviceName,
-			Namespace: "default",
+			Namespace: opts.Namespace,
 			Labels:    labels.Merge(writeLabels, headlessServiceLabels()),
 		},
 		Spec: corev1.ServiceSpec{
@@ -257,15 +272,15 @@ func NewLokiWriteHeadlessService(opts Options) *corev1.Service {
 			Ports: []corev1.ServicePort{
 				{
 					Name:       lokiHTTPPortName,
-					Port:       httpPort,
+					Port:       opts.lokiHTTPPort(),
 					Protocol:   protocolTCP,
-					TargetPort: intstr.IntOrString{StrVal: lokiHTTPPortName},
+					TargetPort: intstr.FromString(lokiHTTPPortName),
 				},
 				{
 					Name:       lokiGRPCPortName,
-					Port:       grpcPort,
+					Port:       opts.lokiGRPCPort(),
 					Protocol:   protocolTCP,
-					TargetPort: intstr.IntOrString{StrVal: lokiGRPCPortName},
+					TargetPort: intstr.FromString(lokiGRPCPortName),
 				},
 			},
 			Selector: writeLabels,
@@ -273,10 +288,10 @@ func NewLokiWriteHeadlessService(opts Options) *corev1.Service {
 	}
 }
 
-// NewQuerierPodDisruptionBudget returns a PodDisruptionBudget for the LokiStack querier pods.
+// NewWritePodDisruptionBudget returns a PodDisruptionBudget for the Loki write pods.
 func NewWritePodDisruptionBudget(opts Options) *policyv1.PodDisruptionBudget {
-	name := "loki-write"
-	labels := commonLabels(opts.Name, "write")
+	name := WriteName(opts.Name)
+	labels := commonLabels(opts.Name, ComponentWrite)
 
 	return &policyv1.PodDisruptionBudget{
 		TypeMeta: metav1.TypeMeta{
@@ -285,7 +300,7 @@ func NewWritePodDisruptionBudget(opts Options) *policyv1.PodDisruptionBudget {
 		},
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      name,
-			Namespace: "default",
+			Namespace: opts.Namespace,
 			Labels:    labels,
 		},
 		Spec: policyv1.PodDisruptionBudgetSpec{
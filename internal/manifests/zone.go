@@ -0,0 +1,158 @@
+package manifests
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	ssdlokiv1 "github.com/ssd-loki/loki-operator/api/v1"
+)
+
+const (
+	// LabelZoneAware marks the pods that wait for their availability zone to be
+	// annotated by the operator before Loki starts.
+	LabelZoneAware = "ssd-loki.ssd-loki.com/zone-aware"
+	// LabelZoneAwareEnabled is the value of LabelZoneAware on zone-aware pods.
+	LabelZoneAwareEnabled = "enabled"
+	// AnnotationAvailabilityZone holds the zone of the node a pod is scheduled on.
+	AnnotationAvailabilityZone = "ssd-loki.ssd-loki.com/availability-zone"
+	// AnnotationAvailabilityZoneLabels holds the comma separated node labels the
+	// zone of a pod is read from.
+	AnnotationAvailabilityZoneLabels = "ssd-loki.ssd-loki.com/availability-zone-labels"
+
+	envAvailabilityZone        = "INSTANCE_AVAILABILITY_ZONE"
+	availabilityZoneVolumeName = "az-annotation"
+	availabilityZoneDirectory  = "/etc/az-annotation"
+	availabilityZoneFileName   = "az"
+	availabilityZoneInitName   = "az-annotation-check"
+)
+
+// zoneAwarenessEnabled returns true when the stack spreads its pods over zones.
+func zoneAwarenessEnabled(stack ssdlokiv1.SsdLoki) bool {
+	r := stack.Spec.Replication
+	return r != nil && len(r.Zones) > 0
+}
+
+// topologySpreadConstraints returns a constraint per zone of the stack, spreading
+// the pods of the given component evenly over the zones.
+func topologySpreadConstraints(opts Options, component string) []corev1.TopologySpreadConstraint {
+	if !zoneAwarenessEnabled(opts.Stack) {
+		return nil
+	}
+
+	zones := opts.Stack.Spec.Replication.Zones
+	tsc := make([]corev1.TopologySpreadConstraint, 0, len(zones))
+	for _, z := range zones {
+		maxSkew := z.MaxSkew
+		if maxSkew < 1 {
+			maxSkew = 1
+		}
+
+		tsc = append(tsc, corev1.TopologySpreadConstraint{
+			MaxSkew:           maxSkew,
+			TopologyKey:       z.TopologyKey,
+			WhenUnsatisfiable: corev1.DoNotSchedule,
+			LabelSelector: &metav1.LabelSelector{
+				MatchLabels: commonLabels(opts.Name, component),
+			},
+		})
+	}
+	return tsc
+}
+
+// configureZoneAwareness makes the ingesters of the write tier register in the
+// ring with the zone of their node. Kubernetes cannot expose node labels to pods,
+// so the operator copies them into an annotation of each scheduled pod. An init
+// container holds Loki back until the annotation is set, after which it is
+// passed to Loki through the downward API.
+func configureZoneAwareness(sts *appsv1.StatefulSet, opts Options) {
+	if !zoneAwarenessEnabled(opts.Stack) {
+		return
+	}
+
+	tpl := &sts.Spec.Template
+	if tpl.Labels == nil {
+		tpl.Labels = map[string]string{}
+	}
+	tpl.Labels[LabelZoneAware] = LabelZoneAwareEnabled
+
+	keys := make([]string, 0, len(opts.Stack.Spec.Replication.Zones))
+	for _, z := range opts.Stack.Spec.Replication.Zones {
+		keys = append(keys, z.TopologyKey)
+	}
+	if tpl.Annotations == nil {
+		tpl.Annotations = map[string]string{}
+	}
+	tpl.Annotations[AnnotationAvailabilityZoneLabels] = strings.Join(keys, ",")
+
+	tpl.Spec.Volumes = append(tpl.Spec.Volumes, corev1.Volume{
+		Name: availabilityZoneVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			DownwardAPI: &corev1.DownwardAPIVolumeSource{
+				Items: []corev1.DownwardAPIVolumeFile{
+					{
+						Path: availabilityZoneFileName,
+						FieldRef: &corev1.ObjectFieldSelector{
+							FieldPath: fmt.Sprintf("metadata.annotations['%s']", AnnotationAvailabilityZone),
+						},
+					},
+				},
+			},
+		},
+	})
+
+	azFile := path.Join(availabilityZoneDirectory, availabilityZoneFileName)
+	tpl.Spec.InitContainers = append(tpl.Spec.InitContainers, corev1.Container{
+		Name:            availabilityZoneInitName,
+		Image:           opts.Image,
+		ImagePullPolicy: corev1.PullIfNotPresent,
+		Command: []string{
+			"sh",
+			"-c",
+			fmt.Sprintf("while ! [ -s %[1]s ]; do echo Waiting for availability zone annotation to be set; sleep 2; done; echo Availability zone annotation is set; cat %[1]s", azFile),
+		},
+		SecurityContext: &corev1.SecurityContext{
+			AllowPrivilegeEscalation: ptr.To(false),
+			Capabilities: &corev1.Capabilities{
+				Drop: []corev1.Capability{"ALL"},
+			},
+			ReadOnlyRootFilesystem: ptr.To(true),
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			{
+				Name:      availabilityZoneVolumeName,
+				MountPath: availabilityZoneDirectory,
+			},
+		},
+	})
+
+	c := &tpl.Spec.Containers[0]
+	c.Env = append(c.Env, corev1.EnvVar{
+		Name: envAvailabilityZone,
+		ValueFrom: &corev1.EnvVarSource{
+			FieldRef: &corev1.ObjectFieldSelector{
+				FieldPath: fmt.Sprintf("metadata.annotations['%s']", AnnotationAvailabilityZone),
+			},
+		},
+	})
+	c.Args = append(c.Args, fmt.Sprintf("-ingester.availability-zone=$(%s)", envAvailabilityZone))
+}
+
+// AvailabilityZone returns the zone of a node, built from the values of the given
+// node labels. It returns false if the node lacks any of the labels.
+func AvailabilityZone(node *corev1.Node, topologyKeys []string) (string, bool) {
+	values := make([]string, 0, len(topologyKeys))
+	for _, key := range topologyKeys {
+		v, ok := node.Labels[key]
+		if !ok || v == "" {
+			return "", false
+		}
+		values = append(values, v)
+	}
+	return strings.Join(values, "_"), true
+}
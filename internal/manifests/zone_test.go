@@ -0,0 +1,107 @@
+package manifests
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ssdlokiv1 "github.com/ssd-loki/loki-operator/api/v1"
+	"github.com/ssd-loki/loki-operator/internal/manifests/internal/config"
+)
+
+func zoneAwareSpec(spec *ssdlokiv1.SsdLokiSpec) {
+	spec.Replication = &ssdlokiv1.ReplicationSpec{
+		Zones: []ssdlokiv1.ZoneSpec{
+			{TopologyKey: "topology.kubernetes.io/zone", MaxSkew: 2},
+			{TopologyKey: "topology.kubernetes.io/region"},
+		},
+	}
+}
+
+func statefulSets(t *testing.T, opts Options) map[string]*appsv1.StatefulSet {
+	objs, err := BuildAll(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sts := map[string]*appsv1.StatefulSet{}
+	for _, obj := range objs {
+		if s, ok := obj.(*appsv1.StatefulSet); ok {
+			sts[s.Name] = s
+		}
+	}
+	return sts
+}
+
+func TestBuildAll_ZonesSpreadWriteAndBackend(t *testing.T) {
+	g := NewWithT(t)
+
+	sts := statefulSets(t, newOptions(t, zoneAwareSpec))
+
+	for _, name := range []string{"loki-write", "loki-backend"} {
+		tsc := sts[name].Spec.Template.Spec.TopologySpreadConstraints
+		g.Expect(tsc).To(HaveLen(2), name)
+		g.Expect(tsc[0].TopologyKey).To(Equal("topology.kubernetes.io/zone"))
+		g.Expect(tsc[0].MaxSkew).To(Equal(int32(2)))
+		g.Expect(tsc[1].MaxSkew).To(Equal(int32(1)))
+		g.Expect(tsc[0].WhenUnsatisfiable).To(Equal(corev1.DoNotSchedule))
+		g.Expect(tsc[0].LabelSelector.MatchLabels).To(Equal(sts[name].Spec.Selector.MatchLabels))
+	}
+	g.Expect(sts["loki-read"].Spec.Template.Spec.TopologySpreadConstraints).To(BeEmpty())
+}
+
+func TestBuildAll_ZoneAwareIngesters(t *testing.T) {
+	g := NewWithT(t)
+
+	write := statefulSets(t, newOptions(t, zoneAwareSpec))["loki-write"]
+	tpl := write.Spec.Template
+
+	g.Expect(tpl.Labels).To(HaveKeyWithValue(LabelZoneAware, LabelZoneAwareEnabled))
+	g.Expect(tpl.Annotations).To(HaveKeyWithValue(
+		AnnotationAvailabilityZoneLabels,
+		"topology.kubernetes.io/zone,topology.kubernetes.io/region",
+	))
+	g.Expect(tpl.Spec.InitContainers).To(HaveLen(1))
+	g.Expect(tpl.Spec.Containers[0].Args).To(ContainElement("-ingester.availability-zone=$(INSTANCE_AVAILABILITY_ZONE)"))
+	g.Expect(tpl.Spec.Containers[0].Env).To(ContainElement(HaveField("Name", "INSTANCE_AVAILABILITY_ZONE")))
+
+	cm, err := LokiConfigMap(newOptions(t, zoneAwareSpec))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(cm.Data[config.LokiConfigFileName]).To(ContainSubstring("zone_awareness_enabled: true"))
+}
+
+func TestBuildAll_NoZonesKeepsDefaults(t *testing.T) {
+	g := NewWithT(t)
+
+	write := statefulSets(t, newOptions(t, nil))["loki-write"]
+	g.Expect(write.Spec.Template.Labels).NotTo(HaveKey(LabelZoneAware))
+	g.Expect(write.Spec.Template.Spec.InitContainers).To(BeEmpty())
+	g.Expect(write.Spec.Template.Spec.TopologySpreadConstraints).To(BeEmpty())
+
+	cm, err := LokiConfigMap(newOptions(t, nil))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(cm.Data[config.LokiConfigFileName]).NotTo(ContainSubstring("zone_awareness_enabled"))
+}
+
+func TestAvailabilityZone(t *testing.T) {
+	g := NewWithT(t)
+
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: map[string]string{
+				"topology.kubernetes.io/region": "eu-west-1",
+				"topology.kubernetes.io/zone":   "eu-west-1a",
+			},
+		},
+	}
+
+	zone, ok := AvailabilityZone(node, []string{"topology.kubernetes.io/region", "topology.kubernetes.io/zone"})
+	g.Expect(ok).To(BeTrue())
+	g.Expect(zone).To(Equal("eu-west-1_eu-west-1a"))
+
+	_, ok = AvailabilityZone(node, []string{"example.com/rack"})
+	g.Expect(ok).To(BeFalse())
+}
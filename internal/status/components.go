@@ -0,0 +1,146 @@
+package status
+
+import (
+	"context"
+
+	"github.com/ViaQ/logerr/kverrors"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ssdlokiv1 "github.com/ssd-loki/loki-operator/api/v1"
+	"github.com/ssd-loki/loki-operator/internal/manifests"
+)
+
+// generateComponentStatus reads the replica counts of the read, write and backend
+// StatefulSets of the stack and of the optional gateway, memcached and canary pods.
+// It also returns the names of the components that are not yet fully rolled out.
+func generateComponentStatus(ctx context.Context, k client.Client, stack *ssdlokiv1.SsdLoki) (*ssdlokiv1.ComponentStatuses, []string, error) {
+	var (
+		cs      ssdlokiv1.ComponentStatuses
+		pending []string
+	)
+
+	tiers := []struct {
+		component string
+		name      string
+		status    *ssdlokiv1.ComponentStatus
+	}{
+		{component: manifests.ComponentRead, name: manifests.ReadName(stack.Name), status: &cs.Read},
+		{component: manifests.ComponentWrite, name: manifests.WriteName(stack.Name), status: &cs.Write},
+		{component: manifests.ComponentBackend, name: manifests.BackendName(stack.Name), status: &cs.Backend},
+	}
+	for _, tier := range tiers {
+		status, ready, err := statefulSetStatus(ctx, k, stack.Namespace, tier.name)
+		if err != nil {
+			return nil, nil, err
+		}
+		*tier.status = status
+		if !ready {
+			pending = append(pending, tier.component)
+		}
+	}
+
+	optional := []struct {
+		component string
+		status    **ssdlokiv1.ComponentStatus
+	}{
+		{component: manifests.ComponentGateway, status: &cs.Gateway},
+		{component: manifests.ComponentMemcached, status: &cs.Memcached},
+		{component: manifests.ComponentCanary, status: &cs.Canary},
+	}
+	for _, comp := range optional {
+		pods, err := componentPods(ctx, k, stack, comp.component)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(pods) == 0 {
+			continue
+		}
+
+		status := podsStatus(pods)
+		*comp.status = &status
+		if status.ReadyReplicas != status.DesiredReplicas {
+			pending = append(pending, comp.component)
+		}
+	}
+
+	return &cs, pending, nil
+}
+
+// statefulSetStatus returns the replica counts of the named StatefulSet and whether
+// all of its desired replicas are updated and ready. A StatefulSet that does not
+// exist yet or whose status lags behind its spec is reported as not ready.
+func statefulSetStatus(ctx context.Context, k client.Client, namespace, name string) (ssdlokiv1.ComponentStatus, bool, error) {
+	var sts appsv1.StatefulSet
+	key := client.ObjectKey{Name: name, Namespace: namespace}
+	if err := k.Get(ctx, key, &sts); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ssdlokiv1.ComponentStatus{}, false, nil
+		}
+		return ssdlokiv1.ComponentStatus{}, false, kverrors.Wrap(err, "failed to lookup statefulset", "name", key)
+	}
+
+	desired := int32(1)
+	if sts.Spec.Replicas != nil {
+		desired = *sts.Spec.Replicas
+	}
+
+	cs := ssdlokiv1.ComponentStatus{
+		DesiredReplicas:   desired,
+		ReadyReplicas:     sts.Status.ReadyReplicas,
+		UpdatedReplicas:   sts.Status.UpdatedReplicas,
+		AvailableReplicas: sts.Status.AvailableReplicas,
+	}
+
+	ready := sts.Status.ObservedGeneration >= sts.Generation &&
+		cs.ReadyReplicas == desired &&
+		cs.UpdatedReplicas == desired
+
+	return cs, ready, nil
+}
+
+// componentPods lists the pods of a component of the stack.
+func componentPods(ctx context.Context, k client.Client, stack *ssdlokiv1.SsdLoki, component string) ([]corev1.Pod, error) {
+	var pods corev1.PodList
+	opts := []client.ListOption{
+		client.InNamespace(stack.Namespace),
+		client.MatchingLabels(manifests.ComponentLabels(stack.Name, component)),
+	}
+	if err := k.List(ctx, &pods, opts...); err != nil {
+		return nil, kverrors.Wrap(err, "failed to list pods", "component", component)
+	}
+	return pods.Items, nil
+}
+
+// podsStatus counts the ready pods of a component that is not managed by one of
+// the tier StatefulSets. Every existing pod counts as desired and updated.
+func podsStatus(pods []corev1.Pod) ssdlokiv1.ComponentStatus {
+	var ready int32
+	for i := range pods {
+		if isPodReady(&pods[i]) {
+			ready++
+		}
+	}
+
+	total := int32(len(pods))
+	return ssdlokiv1.ComponentStatus{
+		DesiredReplicas:   total,
+		UpdatedReplicas:   total,
+		ReadyReplicas:     ready,
+		AvailableReplicas: ready,
+	}
+}
+
+func isPodReady(pod *corev1.Pod) bool {
+	if pod.DeletionTimestamp != nil {
+		return false
+	}
+	for _, c := range pod.Status.Conditions {
+		if c.Type == corev1.PodReady {
+			return c.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
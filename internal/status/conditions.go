@@ -0,0 +1,69 @@
+package status
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ssdlokiv1 "github.com/ssd-loki/loki-operator/api/v1"
+)
+
+const (
+	messageReady   = "All components are ready"
+	messagePending = "Some components are still rolling out: %s"
+)
+
+var conditionTypes = []ssdlokiv1.SsdLokiConditionType{
+	ssdlokiv1.ConditionReady,
+	ssdlokiv1.ConditionProgressing,
+	ssdlokiv1.ConditionDegraded,
+}
+
+// setConditions sets exactly one of the Ready, Progressing and Degraded conditions
+// to true and the others to false, and updates the phase and message accordingly.
+// A degraded error takes precedence over pending components.
+func setConditions(s *ssdlokiv1.SsdLokiStatus, generation int64, now time.Time, pending []string, degraded *DegradedError) {
+	var (
+		active  ssdlokiv1.SsdLokiConditionType
+		reason  ssdlokiv1.SsdLokiConditionReason
+		message string
+	)
+
+	switch {
+	case degraded != nil:
+		active = ssdlokiv1.ConditionDegraded
+		reason = degraded.Reason
+		message = degraded.Message
+		s.Phase = ssdlokiv1.PhaseDegraded
+	case len(pending) > 0:
+		active = ssdlokiv1.ConditionProgressing
+		reason = ssdlokiv1.ReasonPendingComponents
+		message = fmt.Sprintf(messagePending, strings.Join(pending, ", "))
+		s.Phase = ssdlokiv1.PhasePending
+	default:
+		active = ssdlokiv1.ConditionReady
+		reason = ssdlokiv1.ReasonReadyComponents
+		message = messageReady
+		s.Phase = ssdlokiv1.PhaseReady
+	}
+	s.Message = message
+
+	for _, t := range conditionTypes {
+		status := metav1.ConditionFalse
+		if t == active {
+			status = metav1.ConditionTrue
+		}
+
+		meta.SetStatusCondition(&s.Conditions, metav1.Condition{
+			Type:               string(t),
+			Status:             status,
+			ObservedGeneration: generation,
+			LastTransitionTime: metav1.NewTime(now),
+			Reason:             string(reason),
+			Message:            message,
+		})
+	}
+}
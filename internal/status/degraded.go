@@ -0,0 +1,18 @@
+package status
+
+import (
+	"fmt"
+
+	ssdlokiv1 "github.com/ssd-loki/loki-operator/api/v1"
+)
+
+// DegradedError contains information about why the managed SsdLoki has a degraded condition.
+type DegradedError struct {
+	Message string
+	Reason  ssdlokiv1.SsdLokiConditionReason
+	Requeue bool
+}
+
+func (e *DegradedError) Error() string {
+	return fmt.Sprintf("cluster degraded: %s", e.Message)
+}
@@ -0,0 +1,121 @@
+package status
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/ViaQ/logerr/kverrors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ssdlokiv1 "github.com/ssd-loki/loki-operator/api/v1"
+	"github.com/ssd-loki/loki-operator/internal/lokiclient"
+	"github.com/ssd-loki/loki-operator/internal/manifests"
+)
+
+// ringStateActive is the state of an ingester that accepts writes and serves queries.
+const ringStateActive = "ACTIVE"
+
+// ringPage is the JSON form of the ring page returned by Loki for
+// requests accepting application/json.
+type ringPage struct {
+	Shards []struct {
+		ID    string `json:"id"`
+		State string `json:"state"`
+	} `json:"shards"`
+}
+
+// generateRingStatus compares the ingester ring reported by the write tier with
+// the write pods known to Kubernetes. Probe failures are reported in the message
+// of the returned status instead of failing the status refresh, as the ring is
+// expected to be unreachable while the stack starts up.
+func generateRingStatus(ctx context.Context, k client.Client, hc *http.Client, stack *ssdlokiv1.SsdLoki) (*ssdlokiv1.RingStatus, error) {
+	pods, err := componentPods(ctx, k, stack, manifests.ComponentWrite)
+	if err != nil {
+		return nil, err
+	}
+
+	var rs ssdlokiv1.RingStatus
+
+	hc, err = lokiclient.ForStack(ctx, k, hc, stack, manifests.WriteServerName(stack))
+	if err != nil {
+		rs.Message = fmt.Sprintf("failed to configure ring probe: %s", err)
+		return &rs, nil
+	}
+
+	for i := range pods {
+		pod := &pods[i]
+		if isPodReady(pod) {
+			rs.ReadyPods++
+		}
+		if pod.Status.PodIP == "" {
+			continue
+		}
+
+		ready, err := probeReady(ctx, hc, manifests.PodReadyURL(stack, pod.Status.PodIP))
+		if err != nil {
+			rs.Message = fmt.Sprintf("failed to probe pod %s: %s", pod.Name, err)
+			continue
+		}
+		if ready {
+			rs.ReadyEndpoints++
+		}
+	}
+
+	page, err := probeRing(ctx, hc, manifests.IngesterRingURL(stack))
+	if err != nil {
+		rs.Message = fmt.Sprintf("failed to probe ingester ring: %s", err)
+		return &rs, nil
+	}
+
+	for _, shard := range page.Shards {
+		rs.Members++
+		if shard.State == ringStateActive {
+			rs.ActiveMembers++
+		}
+	}
+
+	return &rs, nil
+}
+
+func probeRing(ctx context.Context, hc *http.Client, url string) (*ringPage, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, kverrors.Wrap(err, "failed to create ring request", "url", url)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, kverrors.New("unexpected ring response", "url", url, "status", resp.StatusCode)
+	}
+
+	var page ringPage
+	if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+		return nil, kverrors.Wrap(err, "failed to decode ring response", "url", url)
+	}
+	return &page, nil
+}
+
+func probeReady(ctx context.Context, hc *http.Client, url string) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return false, kverrors.Wrap(err, "failed to create ready request", "url", url)
+	}
+
+	resp, err := hc.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	return resp.StatusCode == http.StatusOK, nil
+}
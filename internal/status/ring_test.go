@@ -0,0 +1,92 @@
+package status
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ssdlokiv1 "github.com/ssd-loki/loki-operator/api/v1"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// newLokiClient returns an HTTP client answering every request with the handler,
+// regardless of the requested host.
+func newLokiClient(h http.Handler) *http.Client {
+	return &http.Client{
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+			return rec.Result(), nil
+		}),
+	}
+}
+
+func TestGenerateRingStatus(t *testing.T) {
+	g := NewWithT(t)
+
+	stack := &ssdlokiv1.SsdLoki{
+		ObjectMeta: metav1.ObjectMeta{Name: "loki", Namespace: "ns"},
+	}
+	k := newTestClient(t,
+		stack,
+		newPod("loki-write-0", "write", "10.0.0.1", true),
+		newPod("loki-write-1", "write", "10.0.0.2", true),
+		newPod("loki-write-2", "write", "10.0.0.3", false),
+	)
+
+	hc := newLokiClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/ring":
+			g.Expect(r.Host).To(Equal("loki-write.ns.svc.cluster.local:3100"))
+			g.Expect(r.Header.Get("Accept")).To(Equal("application/json"))
+			_, _ = w.Write([]byte(`{"shards":[
+				{"id":"loki-write-0","state":"ACTIVE"},
+				{"id":"loki-write-1","state":"ACTIVE"},
+				{"id":"loki-write-2","state":"JOINING"}
+			]}`))
+		case r.URL.Path == "/ready" && r.Host == "10.0.0.3:3100":
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case r.URL.Path == "/ready":
+			_, _ = w.Write([]byte("ready"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	rs, err := generateRingStatus(context.Background(), k, hc, stack)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(rs).To(Equal(&ssdlokiv1.RingStatus{
+		Members:        3,
+		ActiveMembers:  2,
+		ReadyPods:      2,
+		ReadyEndpoints: 2,
+	}))
+}
+
+func TestGenerateRingStatus_ProbeFailure(t *testing.T) {
+	g := NewWithT(t)
+
+	stack := &ssdlokiv1.SsdLoki{
+		ObjectMeta: metav1.ObjectMeta{Name: "loki", Namespace: "ns"},
+	}
+	k := newTestClient(t, stack, newPod("loki-write-0", "write", "", true))
+
+	hc := newLokiClient(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+
+	rs, err := generateRingStatus(context.Background(), k, hc, stack)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(rs.ReadyPods).To(Equal(int32(1)))
+	g.Expect(rs.Members).To(BeZero())
+	g.Expect(rs.Message).To(ContainSubstring("ingester ring"))
+}
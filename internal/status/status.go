@@ -0,0 +1,53 @@
+package status
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/ViaQ/logerr/kverrors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ssdlokiv1 "github.com/ssd-loki/loki-operator/api/v1"
+)
+
+// Refresh executes an aggregate update of the SsdLoki status struct, i.e.
+// - It recreates the Status.ComponentStatuses from the owned StatefulSets and pods.
+// - It probes the ingester ring of the write tier, unless hc is nil.
+// - It sets the Ready, Progressing and Degraded conditions and the phase.
+// - It records the generation of the spec the status was computed for.
+func Refresh(ctx context.Context, k client.Client, hc *http.Client, req ctrl.Request, now time.Time, degradedErr *DegradedError) error {
+	var stack ssdlokiv1.SsdLoki
+	if err := k.Get(ctx, req.NamespacedName, &stack); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return kverrors.Wrap(err, "failed to lookup ssdloki", "name", req.NamespacedName)
+	}
+
+	cs, pending, err := generateComponentStatus(ctx, k, &stack)
+	if err != nil {
+		return err
+	}
+
+	stack.Status.ComponentStatuses = cs
+
+	if hc != nil {
+		ring, err := generateRingStatus(ctx, k, hc, &stack)
+		if err != nil {
+			return err
+		}
+		stack.Status.IngesterRing = ring
+	}
+
+	stack.Status.ObservedGeneration = stack.Generation
+	setConditions(&stack.Status, stack.Generation, now, pending, degradedErr)
+
+	if err := k.Status().Update(ctx, &stack); err != nil {
+		return kverrors.Wrap(err, "failed to update ssdloki status", "name", req.NamespacedName)
+	}
+
+	return nil
+}
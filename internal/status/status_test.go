@@ -0,0 +1,205 @@
+package status
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	ssdlokiv1 "github.com/ssd-loki/loki-operator/api/v1"
+	"github.com/ssd-loki/loki-operator/internal/manifests"
+)
+
+func newTestClient(t *testing.T, objs ...client.Object) client.Client {
+	s := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	if err := ssdlokiv1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+
+	return fake.NewClientBuilder().
+		WithScheme(s).
+		WithObjects(objs...).
+		WithStatusSubresource(&ssdlokiv1.SsdLoki{}).
+		Build()
+}
+
+func newStatefulSet(name string, replicas, ready int32) *appsv1.StatefulSet {
+	return &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns"},
+		Spec:       appsv1.StatefulSetSpec{Replicas: ptr.To(replicas)},
+		Status: appsv1.StatefulSetStatus{
+			ReadyReplicas:     ready,
+			UpdatedReplicas:   ready,
+			AvailableReplicas: ready,
+		},
+	}
+}
+
+func newPod(name, component, ip string, ready bool) *corev1.Pod {
+	status := corev1.ConditionFalse
+	if ready {
+		status = corev1.ConditionTrue
+	}
+
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "ns",
+			Labels:    manifests.ComponentLabels("loki", component),
+		},
+		Status: corev1.PodStatus{
+			PodIP: ip,
+			Conditions: []corev1.PodCondition{
+				{Type: corev1.PodReady, Status: status},
+			},
+		},
+	}
+}
+
+func TestRefresh(t *testing.T) {
+	tt := []struct {
+		desc       string
+		readReady  int32
+		degraded   *DegradedError
+		wantPhase  ssdlokiv1.SsdLokiPhase
+		wantActive ssdlokiv1.SsdLokiConditionType
+		wantReason ssdlokiv1.SsdLokiConditionReason
+	}{
+		{
+			desc:       "all replicas ready",
+			readReady:  3,
+			wantPhase:  ssdlokiv1.PhaseReady,
+			wantActive: ssdlokiv1.ConditionReady,
+			wantReason: ssdlokiv1.ReasonReadyComponents,
+		},
+		{
+			desc:       "read replicas pending",
+			readReady:  1,
+			wantPhase:  ssdlokiv1.PhasePending,
+			wantActive: ssdlokiv1.ConditionProgressing,
+			wantReason: ssdlokiv1.ReasonPendingComponents,
+		},
+		{
+			desc:      "degraded error",
+			readReady: 3,
+			degraded: &DegradedError{
+				Message: "Missing object storage secret",
+				Reason:  ssdlokiv1.ReasonMissingObjectStorageSecret,
+			},
+			wantPhase:  ssdlokiv1.PhaseDegraded,
+			wantActive: ssdlokiv1.ConditionDegraded,
+			wantReason: ssdlokiv1.ReasonMissingObjectStorageSecret,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.desc, func(t *testing.T) {
+			g := NewWithT(t)
+
+			stack := &ssdlokiv1.SsdLoki{
+				ObjectMeta: metav1.ObjectMeta{Name: "loki", Namespace: "ns", Generation: 2},
+			}
+			k := newTestClient(t,
+				stack,
+				newStatefulSet("loki-read", 3, tc.readReady),
+				newStatefulSet("loki-write", 3, 3),
+				newStatefulSet("loki-backend", 3, 3),
+			)
+			req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(stack)}
+
+			err := Refresh(context.Background(), k, nil, req, time.Now(), tc.degraded)
+			g.Expect(err).NotTo(HaveOccurred())
+
+			var got ssdlokiv1.SsdLoki
+			g.Expect(k.Get(context.Background(), req.NamespacedName, &got)).To(Succeed())
+
+			g.Expect(got.Status.Phase).To(Equal(tc.wantPhase))
+			g.Expect(got.Status.ObservedGeneration).To(Equal(int64(2)))
+			g.Expect(got.Status.ComponentStatuses.Read.ReadyReplicas).To(Equal(tc.readReady))
+			g.Expect(got.Status.ComponentStatuses.Write.DesiredReplicas).To(Equal(int32(3)))
+			g.Expect(got.Status.Conditions).To(HaveLen(3))
+
+			for _, c := range got.Status.Conditions {
+				g.Expect(c.Reason).To(Equal(string(tc.wantReason)))
+				g.Expect(c.ObservedGeneration).To(Equal(int64(2)))
+				if c.Type == string(tc.wantActive) {
+					g.Expect(c.Status).To(Equal(metav1.ConditionTrue))
+				} else {
+					g.Expect(c.Status).To(Equal(metav1.ConditionFalse))
+				}
+			}
+		})
+	}
+}
+
+func TestRefresh_MissingStatefulSetIsPending(t *testing.T) {
+	g := NewWithT(t)
+
+	stack := &ssdlokiv1.SsdLoki{
+		ObjectMeta: metav1.ObjectMeta{Name: "loki", Namespace: "ns"},
+	}
+	k := newTestClient(t,
+		stack,
+		newStatefulSet("loki-read", 3, 3),
+		newStatefulSet("loki-write", 3, 3),
+	)
+	req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(stack)}
+
+	g.Expect(Refresh(context.Background(), k, nil, req, time.Now(), nil)).To(Succeed())
+
+	var got ssdlokiv1.SsdLoki
+	g.Expect(k.Get(context.Background(), req.NamespacedName, &got)).To(Succeed())
+
+	cond := meta.FindStatusCondition(got.Status.Conditions, string(ssdlokiv1.ConditionProgressing))
+	g.Expect(cond).NotTo(BeNil())
+	g.Expect(cond.Status).To(Equal(metav1.ConditionTrue))
+	g.Expect(cond.Message).To(ContainSubstring("backend"))
+}
+
+func TestRefresh_OptionalComponentPods(t *testing.T) {
+	g := NewWithT(t)
+
+	stack := &ssdlokiv1.SsdLoki{
+		ObjectMeta: metav1.ObjectMeta{Name: "loki", Namespace: "ns"},
+	}
+	k := newTestClient(t,
+		stack,
+		newStatefulSet("loki-read", 1, 1),
+		newStatefulSet("loki-write", 1, 1),
+		newStatefulSet("loki-backend", 1, 1),
+		newPod("loki-gateway-a", "gateway", "", true),
+		newPod("loki-gateway-b", "gateway", "", false),
+	)
+	req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(stack)}
+
+	g.Expect(Refresh(context.Background(), k, nil, req, time.Now(), nil)).To(Succeed())
+
+	var got ssdlokiv1.SsdLoki
+	g.Expect(k.Get(context.Background(), req.NamespacedName, &got)).To(Succeed())
+
+	cs := got.Status.ComponentStatuses
+	g.Expect(cs.Gateway).To(Equal(&ssdlokiv1.ComponentStatus{
+		DesiredReplicas:   2,
+		UpdatedReplicas:   2,
+		ReadyReplicas:     1,
+		AvailableReplicas: 1,
+	}))
+	g.Expect(cs.Memcached).To(BeNil())
+	g.Expect(cs.Canary).To(BeNil())
+	g.Expect(got.Status.Phase).To(Equal(ssdlokiv1.PhasePending))
+	g.Expect(got.Status.Message).To(ContainSubstring("gateway"))
+}
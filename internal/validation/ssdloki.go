@@ -0,0 +1,492 @@
+package validation
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/prometheus/common/model"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	ssdlokiv1 "github.com/ssd-loki/loki-operator/api/v1"
+	"github.com/ssd-loki/loki-operator/internal/manifests"
+	"github.com/ssd-loki/loki-operator/internal/manifests/storage"
+)
+
+// schemaDateFormat is the layout of the from date of a schema config entry.
+const schemaDateFormat = "2006-01-02"
+
+var (
+	// chunkEncodings are the chunk encodings supported by the Loki ingester.
+	chunkEncodings = []string{"none", "gzip", "lz4-64k", "lz4-256k", "lz4-1M", "lz4", "flate", "snappy", "zstd"}
+	// indexGatewayModes are the modes supported by the Loki index gateway.
+	indexGatewayModes = []string{"simple", "ring"}
+)
+
+//+kubebuilder:webhook:path=/validate-ssd-loki-ssd-loki-com-v1-ssdloki,mutating=false,failurePolicy=fail,sideEffects=None,groups=ssd-loki.ssd-loki.com,resources=ssdlokis,verbs=create;update,versions=v1,name=vssdloki.ssd-loki.com,admissionReviewVersions=v1
+
+var _ admission.CustomValidator = &SsdLokiValidator{}
+
+// SsdLokiValidator implements a custom validator for SsdLoki resources.
+type SsdLokiValidator struct{}
+
+// SetupWebhookWithManager registers the SsdLokiValidator as a validating webhook
+// with the controller-runtime manager or returns an error.
+func (v *SsdLokiValidator) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&ssdlokiv1.SsdLoki{}).
+		WithValidator(v).
+		Complete()
+}
+
+// ValidateCreate implements admission.CustomValidator.
+func (v *SsdLokiValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return v.validate(ctx, obj)
+}
+
+// ValidateUpdate implements admission.CustomValidator.
+func (v *SsdLokiValidator) ValidateUpdate(ctx context.Context, _, newObj runtime.Object) (admission.Warnings, error) {
+	return v.validate(ctx, newObj)
+}
+
+// ValidateDelete implements admission.CustomValidator.
+func (v *SsdLokiValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	// No validation on delete
+	return nil, nil
+}
+
+func (v *SsdLokiValidator) validate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	stack, ok := obj.(*ssdlokiv1.SsdLoki)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("object is not of type SsdLoki: %T", obj))
+	}
+
+	var allErrs field.ErrorList
+	specPath := field.NewPath("spec")
+	spec := stack.Spec
+
+	allErrs = append(allErrs, validateLimits(spec.LimitsConfig, specPath.Child("limitsConfig"))...)
+	allErrs = append(allErrs, validateOverrides(spec.Overrides, specPath.Child("overrides"))...)
+	allErrs = append(allErrs, validateRetention(spec.Retention, specPath.Child("retention"))...)
+	allErrs = append(allErrs, validateHedging(spec.StorageConfig, specPath.Child("storageConfig"))...)
+	allErrs = append(allErrs, ValidateSchemas(spec.SchemaConfig, specPath.Child("schemaConfig"))...)
+	allErrs = append(allErrs, validateIngester(spec.Ingester, specPath.Child("ingester"))...)
+	allErrs = append(allErrs, validateIndexGateway(spec.IndexGateway, specPath.Child("indexGateway"))...)
+	allErrs = append(allErrs, validateStorage(spec.Common, specPath.Child("common", "storage"))...)
+	allErrs = append(allErrs, validateCaches(spec, specPath)...)
+	allErrs = append(allErrs, validateZones(spec.Replication, specPath.Child("replication", "zones"))...)
+	allErrs = append(allErrs, validateGateway(spec.Gateway, specPath.Child("gateway"))...)
+	allErrs = append(allErrs, validateInternalTLS(spec.TLS, specPath.Child("tls"))...)
+	allErrs = append(allErrs, validateRules(spec, specPath)...)
+	allErrs = append(allErrs, validateRulerAlertManager(spec.Ruler, specPath.Child("ruler"))...)
+	allErrs = append(allErrs, validateRulerRemoteWrite(spec.Ruler, specPath.Child("ruler", "remoteWrite"))...)
+
+	// The remaining checks need the effective spec with size presets and
+	// defaults applied, which requires the checks above to pass.
+	if len(allErrs) == 0 {
+		allErrs = append(allErrs, validateReplicationFactor(stack, specPath)...)
+	}
+
+	if len(allErrs) == 0 {
+		return nil, nil
+	}
+
+	return nil, apierrors.NewInvalid(
+		ssdlokiv1.GroupVersion.WithKind("SsdLoki").GroupKind(),
+		stack.Name,
+		allErrs,
+	)
+}
+
+func validateLimits(l *ssdlokiv1.LimitsConfig, p *field.Path) field.ErrorList {
+	if l == nil {
+		return nil
+	}
+
+	var errs field.ErrorList
+	errs = append(errs, validateModelDuration(l.MaxCacheFreshnessPerQuery, p.Child("maxCacheFreshnessPerQuery"))...)
+	errs = append(errs, validateModelDuration(l.QueryTimeout, p.Child("queryTimeout"))...)
+	errs = append(errs, validateModelDuration(l.RejectOldSamplesMaxAge, p.Child("rejectOldSamplesMaxAge"))...)
+	errs = append(errs, validateModelDuration(l.SplitQueriesByInterval, p.Child("splitQueriesByInterval"))...)
+	return errs
+}
+
+func validateOverrides(overrides map[string]ssdlokiv1.PerTenantLimitsConfig, p *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	for tenant, l := range overrides {
+		tp := p.Key(tenant)
+		errs = append(errs, validateModelDuration(l.MaxQueryLength, tp.Child("maxQueryLength"))...)
+		errs = append(errs, validateModelDuration(l.MaxCacheFreshnessPerQuery, tp.Child("maxCacheFreshnessPerQuery"))...)
+		errs = append(errs, validateModelDuration(l.QueryTimeout, tp.Child("queryTimeout"))...)
+		errs = append(errs, validateModelDuration(l.RejectOldSamplesMaxAge, tp.Child("rejectOldSamplesMaxAge"))...)
+		errs = append(errs, validateModelDuration(l.SplitQueriesByInterval, tp.Child("splitQueriesByInterval"))...)
+		errs = append(errs, validateRetentionLimits(l.Retention, tp.Child("retention"))...)
+	}
+	return errs
+}
+
+func validateRetention(r *ssdlokiv1.RetentionSpec, p *field.Path) field.ErrorList {
+	if r == nil {
+		return nil
+	}
+	return validateRetentionLimits(&r.RetentionLimitSpec, p)
+}
+
+func validateRetentionLimits(r *ssdlokiv1.RetentionLimitSpec, p *field.Path) field.ErrorList {
+	if r == nil {
+		return nil
+	}
+
+	var errs field.ErrorList
+	errs = append(errs, validateRetentionPeriod(string(r.Period), p.Child("period"))...)
+	for i, st := range r.Streams {
+		errs = append(errs, validateRetentionPeriod(string(st.Period), p.Child("streams").Index(i).Child("period"))...)
+	}
+	return errs
+}
+
+// validateRetentionPeriod rejects periods the compactor refuses, as it deletes
+// whole index tables of 24h.
+func validateRetentionPeriod(value string, p *field.Path) field.ErrorList {
+	if value == "" {
+		return nil
+	}
+
+	d, err := model.ParseDuration(value)
+	if err != nil {
+		return field.ErrorList{field.Invalid(p, value, err.Error())}
+	}
+	if d != 0 && time.Duration(d) < 24*time.Hour {
+		return field.ErrorList{field.Invalid(p, value, "must be at least 24h")}
+	}
+	return nil
+}
+
+func validateHedging(s *ssdlokiv1.StorageConfig, p *field.Path) field.ErrorList {
+	if s == nil || s.Hedging == nil || s.Hedging.At == "" {
+		return nil
+	}
+
+	if _, err := time.ParseDuration(s.Hedging.At); err != nil {
+		return field.ErrorList{field.Invalid(p.Child("hedging", "at"), s.Hedging.At, err.Error())}
+	}
+	return nil
+}
+
+// ValidateSchemas checks that the from dates of the schema config entries are valid
+// dates in ascending order. It is also used by the reconciler, which does not rely
+// on the webhook being deployed.
+func ValidateSchemas(s *ssdlokiv1.SchemaConfig, p *field.Path) field.ErrorList {
+	if s == nil {
+		return nil
+	}
+
+	var (
+		errs field.ErrorList
+		prev time.Time
+	)
+	for i, entry := range s.Configs {
+		fp := p.Child("configs").Index(i).Child("from")
+
+		from, err := time.Parse(schemaDateFormat, entry.From)
+		if err != nil {
+			errs = append(errs, field.Invalid(fp, entry.From, "must be a date in the format YYYY-MM-DD"))
+			continue
+		}
+
+		if i > 0 && !from.After(prev) {
+			errs = append(errs, field.Invalid(fp, entry.From, "must be after the date of the previous schema"))
+		}
+		prev = from
+	}
+	return errs
+}
+
+func validateIngester(i *ssdlokiv1.IngesterConfig, p *field.Path) field.ErrorList {
+	if i == nil || i.ChunkEncoding == "" {
+		return nil
+	}
+
+	if !slices.Contains(chunkEncodings, i.ChunkEncoding) {
+		return field.ErrorList{field.NotSupported(p.Child("chunkEncoding"), i.ChunkEncoding, chunkEncodings)}
+	}
+	return nil
+}
+
+func validateIndexGateway(i *ssdlokiv1.IndexGatewayConfig, p *field.Path) field.ErrorList {
+	if i == nil || i.Mode == "" {
+		return nil
+	}
+
+	if !slices.Contains(indexGatewayModes, i.Mode) {
+		return field.ErrorList{field.NotSupported(p.Child("mode"), i.Mode, indexGatewayModes)}
+	}
+	return nil
+}
+
+func validateStorage(c *ssdlokiv1.CommonConfig, p *field.Path) field.ErrorList {
+	if c == nil {
+		return nil
+	}
+
+	if _, err := storage.TypeOf(c.Storage); err != nil {
+		return field.ErrorList{field.Invalid(p, "", "only one object storage backend may be set")}
+	}
+	return nil
+}
+
+func validateCaches(spec ssdlokiv1.SsdLokiSpec, p *field.Path) field.ErrorList {
+	var errs field.ErrorList
+
+	if spec.ChunkStoreConfig != nil {
+		if cc := spec.ChunkStoreConfig.ChunkCacheConfig; cc != nil {
+			errs = append(errs, validateCacheMode(cc.Mode, cc.MemcachedClient, p.Child("chunkStoreConfig", "chunkCacheConfig"))...)
+		}
+	}
+	if spec.QueryRange != nil && spec.QueryRange.ResultsCache != nil {
+		if rc := spec.QueryRange.ResultsCache.Cache; rc != nil {
+			errs = append(errs, validateCacheMode(rc.Mode, rc.MemcachedClient, p.Child("queryRange", "resultsCache", "cache"))...)
+		}
+	}
+	return errs
+}
+
+func validateCacheMode(mode ssdlokiv1.CacheMode, mc *ssdlokiv1.MemcachedClientConfig, p *field.Path) field.ErrorList {
+	hasAddresses := mc != nil && mc.Addresses != ""
+
+	switch {
+	case mode == ssdlokiv1.CacheModeExternal && !hasAddresses:
+		return field.ErrorList{field.Required(p.Child("memcachedClient", "addresses"), "external caches require memcached addresses")}
+	case mode == ssdlokiv1.CacheModeManaged && hasAddresses:
+		return field.ErrorList{field.Forbidden(p.Child("memcachedClient", "addresses"), "managed caches set the memcached addresses")}
+	}
+	return nil
+}
+
+func validateZones(r *ssdlokiv1.ReplicationSpec, p *field.Path) field.ErrorList {
+	if r == nil {
+		return nil
+	}
+
+	var errs field.ErrorList
+	seen := map[string]bool{}
+	for i, z := range r.Zones {
+		fp := p.Index(i).Child("topologyKey")
+		if seen[z.TopologyKey] {
+			errs = append(errs, field.Duplicate(fp, z.TopologyKey))
+		}
+		seen[z.TopologyKey] = true
+	}
+	return errs
+}
+
+// validateGateway checks that an enabled gateway has tenants, that every tenant is
+// authenticated by exactly one of OIDC and mTLS, and that roles only reference
+// known tenants.
+func validateGateway(gw *ssdlokiv1.GatewaySpec, p *field.Path) field.ErrorList {
+	if gw == nil || !gw.Enabled {
+		return nil
+	}
+
+	tp := p.Child("tenants")
+	if gw.Tenants == nil || len(gw.Tenants.Authentication) == 0 {
+		return field.ErrorList{field.Required(tp.Child("authentication"), "the gateway requires at least one tenant")}
+	}
+
+	var errs field.ErrorList
+	tenants := map[string]bool{}
+	for i, a := range gw.Tenants.Authentication {
+		ap := tp.Child("authentication").Index(i)
+		if tenants[a.TenantName] {
+			errs = append(errs, field.Duplicate(ap.Child("tenantName"), a.TenantName))
+		}
+		tenants[a.TenantName] = true
+
+		switch {
+		case a.OIDC == nil && a.MTLS == nil:
+			errs = append(errs, field.Required(ap, "one of oidc and mTLS is required"))
+		case a.OIDC != nil && a.MTLS != nil:
+			errs = append(errs, field.Forbidden(ap.Child("mTLS"), "only one of oidc and mTLS may be set"))
+		case a.MTLS != nil && gw.TLS == nil:
+			errs = append(errs, field.Required(p.Child("tls"), "mTLS tenants require the gateway to serve TLS"))
+		}
+	}
+
+	if az := gw.Tenants.Authorization; az != nil {
+		for i, r := range az.Roles {
+			for j, t := range r.Tenants {
+				if !tenants[t] {
+					errs = append(errs, field.NotFound(tp.Child("authorization", "roles").Index(i).Child("tenants").Index(j), t))
+				}
+			}
+		}
+	}
+
+	return errs
+}
+
+// validateInternalTLS checks that enabled internal TLS references a CA and a
+// certificate for every tier.
+func validateInternalTLS(t *ssdlokiv1.InternalTLSSpec, p *field.Path) field.ErrorList {
+	if t == nil || !t.Enabled {
+		return nil
+	}
+
+	var errs field.ErrorList
+	if t.CA == nil {
+		errs = append(errs, field.Required(p.Child("ca"), "internal TLS requires a CA"))
+	}
+
+	cp := p.Child("certificates")
+	if t.Certificates == nil {
+		return append(errs, field.Required(cp, "internal TLS requires a certificate for every tier"))
+	}
+	for _, c := range []struct {
+		name   string
+		secret string
+	}{
+		{name: "read", secret: t.Certificates.Read},
+		{name: "write", secret: t.Certificates.Write},
+		{name: "backend", secret: t.Certificates.Backend},
+	} {
+		if c.secret == "" {
+			errs = append(errs, field.Required(cp.Child(c.name), "internal TLS requires a certificate for every tier"))
+		}
+	}
+	return errs
+}
+
+func validateRules(spec ssdlokiv1.SsdLokiSpec, p *field.Path) field.ErrorList {
+	r := spec.Rules
+	if r == nil || !r.Enabled {
+		return nil
+	}
+
+	rp := p.Child("rules")
+	var errs field.ErrorList
+	if r.Selector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(r.Selector); err != nil {
+			errs = append(errs, field.Invalid(rp.Child("selector"), r.Selector, err.Error()))
+		}
+	}
+	if r.NamespaceSelector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(r.NamespaceSelector); err != nil {
+			errs = append(errs, field.Invalid(rp.Child("namespaceSelector"), r.NamespaceSelector, err.Error()))
+		}
+	}
+	// The selected rules are mounted into the local rule storage of the ruler.
+	if spec.Ruler != nil && spec.Ruler.Storage != nil && spec.Ruler.Storage.Type != "local" {
+		errs = append(errs, field.Invalid(p.Child("ruler", "storage", "type"), spec.Ruler.Storage.Type,
+			"the ruler storage must be local when rules are enabled"))
+	}
+	return errs
+}
+
+func validateRulerAlertManager(r *ssdlokiv1.RulerConfig, p *field.Path) field.ErrorList {
+	if r == nil {
+		return nil
+	}
+
+	var errs field.ErrorList
+	if r.AlertManager != nil {
+		errs = append(errs, validateAlertManagerEndpoints(r.AlertManager.Endpoints, p.Child("alertmanager", "endpoints"))...)
+	}
+	for tenant, o := range r.Overrides {
+		if o.AlertManager == nil {
+			continue
+		}
+		ap := p.Child("overrides").Key(tenant).Child("alertmanager")
+		errs = append(errs, validateAlertManagerEndpoints(o.AlertManager.Endpoints, ap.Child("endpoints"))...)
+		if c := o.AlertManager.Client; c != nil && c.BasicAuth != nil {
+			errs = append(errs, field.Forbidden(ap.Child("client", "basicAuth"),
+				"basic auth is not supported in tenant overrides, use headerAuth instead"))
+		}
+	}
+	return errs
+}
+
+func validateAlertManagerEndpoints(endpoints []string, p *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	for i, e := range endpoints {
+		if !isAbsoluteURL(e) {
+			errs = append(errs, field.Invalid(p.Index(i), e, "must be an absolute URL"))
+		}
+	}
+	return errs
+}
+
+func validateRulerRemoteWrite(r *ssdlokiv1.RulerConfig, p *field.Path) field.ErrorList {
+	if r == nil || r.RemoteWrite == nil || !r.RemoteWrite.Enabled {
+		return nil
+	}
+
+	var errs field.ErrorList
+	rw := r.RemoteWrite
+	c := rw.Client
+	cp := p.Child("client")
+	if !isAbsoluteURL(c.URL) {
+		errs = append(errs, field.Invalid(cp.Child("url"), c.URL, "must be an absolute URL"))
+	}
+	if c.ProxyURL != "" && !isAbsoluteURL(c.ProxyURL) {
+		errs = append(errs, field.Invalid(cp.Child("proxyUrl"), c.ProxyURL, "must be an absolute URL"))
+	}
+	if c.AuthorizationType != "" && c.AuthorizationSecretName == "" {
+		errs = append(errs, field.Required(cp.Child("authorizationSecretName"),
+			"required when authorization is set"))
+	}
+	if c.AuthorizationType == "" && c.AuthorizationSecretName != "" {
+		errs = append(errs, field.Required(cp.Child("authorization"),
+			"required when authorizationSecretName is set"))
+	}
+	if q := rw.Queue; q != nil && q.MinShards > 0 && q.MaxShards > 0 && q.MinShards > q.MaxShards {
+		errs = append(errs, field.Invalid(p.Child("queue", "minShards"), q.MinShards,
+			fmt.Sprintf("must not be greater than maxShards (%d)", q.MaxShards)))
+	}
+	return errs
+}
+
+func isAbsoluteURL(s string) bool {
+	u, err := url.Parse(s)
+	return err == nil && u.Scheme != "" && u.Host != ""
+}
+
+func validateReplicationFactor(stack *ssdlokiv1.SsdLoki, p *field.Path) field.ErrorList {
+	opts := manifests.Options{
+		Name:      stack.Name,
+		Namespace: stack.Namespace,
+		Stack:     *stack.DeepCopy(),
+	}
+	if err := manifests.ApplyDefaultSettings(&opts); err != nil {
+		return field.ErrorList{field.Invalid(p, "", err.Error())}
+	}
+
+	spec := opts.Stack.Spec
+	rf := spec.Common.ReplicationFactor
+	replicas := *spec.Template.Write.Replicas
+	if int32(rf) > replicas {
+		return field.ErrorList{field.Invalid(
+			p.Child("common", "replicationFactor"),
+			rf,
+			fmt.Sprintf("must not be larger than the number of write replicas (%d)", replicas),
+		)}
+	}
+	return nil
+}
+
+func validateModelDuration(value string, p *field.Path) field.ErrorList {
+	if value == "" {
+		return nil
+	}
+
+	if _, err := model.ParseDuration(value); err != nil {
+		return field.ErrorList{field.Invalid(p, value, err.Error())}
+	}
+	return nil
+}
@@ -0,0 +1,374 @@
+package validation
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	ssdlokiv1 "github.com/ssd-loki/loki-operator/api/v1"
+)
+
+func TestSsdLokiValidator_ValidateCreate(t *testing.T) {
+	tt := []struct {
+		desc      string
+		spec      ssdlokiv1.SsdLokiSpec
+		wantField string
+	}{
+		{
+			desc: "empty spec",
+		},
+		{
+			desc: "valid durations",
+			spec: ssdlokiv1.SsdLokiSpec{
+				LimitsConfig: &ssdlokiv1.LimitsConfig{
+					QueryTimeout:           "5m",
+					RejectOldSamplesMaxAge: "7d",
+				},
+				StorageConfig: &ssdlokiv1.StorageConfig{
+					Hedging: &ssdlokiv1.HedgingConfig{At: "250ms"},
+				},
+			},
+		},
+		{
+			desc: "invalid limits duration",
+			spec: ssdlokiv1.SsdLokiSpec{
+				LimitsConfig: &ssdlokiv1.LimitsConfig{QueryTimeout: "five minutes"},
+			},
+			wantField: "spec.limitsConfig.queryTimeout",
+		},
+		{
+			desc: "invalid override duration",
+			spec: ssdlokiv1.SsdLokiSpec{
+				Overrides: map[string]ssdlokiv1.PerTenantLimitsConfig{
+					"team-a": {MaxQueryLength: "1x"},
+				},
+			},
+			wantField: "spec.overrides[team-a].maxQueryLength",
+		},
+		{
+			desc: "invalid hedging duration",
+			spec: ssdlokiv1.SsdLokiSpec{
+				StorageConfig: &ssdlokiv1.StorageConfig{
+					Hedging: &ssdlokiv1.HedgingConfig{At: "soon"},
+				},
+			},
+			wantField: "spec.storageConfig.hedging.at",
+		},
+		{
+			desc: "schema date not ISO",
+			spec: ssdlokiv1.SsdLokiSpec{
+				SchemaConfig: &ssdlokiv1.SchemaConfig{
+					Configs: []ssdlokiv1.SchemaConfigEntry{{From: "01/04/2024"}},
+				},
+			},
+			wantField: "spec.schemaConfig.configs[0].from",
+		},
+		{
+			desc: "schema dates not ascending",
+			spec: ssdlokiv1.SsdLokiSpec{
+				SchemaConfig: &ssdlokiv1.SchemaConfig{
+					Configs: []ssdlokiv1.SchemaConfigEntry{
+						{From: "2024-04-01"},
+						{From: "2024-01-01"},
+					},
+				},
+			},
+			wantField: "spec.schemaConfig.configs[1].from",
+		},
+		{
+			desc: "unknown chunk encoding",
+			spec: ssdlokiv1.SsdLokiSpec{
+				Ingester: &ssdlokiv1.IngesterConfig{ChunkEncoding: "brotli"},
+			},
+			wantField: "spec.ingester.chunkEncoding",
+		},
+		{
+			desc: "unknown index gateway mode",
+			spec: ssdlokiv1.SsdLokiSpec{
+				IndexGateway: &ssdlokiv1.IndexGatewayConfig{Mode: "sharded"},
+			},
+			wantField: "spec.indexGateway.mode",
+		},
+		{
+			desc: "several storage backends",
+			spec: ssdlokiv1.SsdLokiSpec{
+				Common: &ssdlokiv1.CommonConfig{
+					Storage: &ssdlokiv1.CommonStorage{
+						S3:  &ssdlokiv1.S3Config{},
+						GCS: &ssdlokiv1.GCSConfig{},
+					},
+				},
+			},
+			wantField: "spec.common.storage",
+		},
+		{
+			desc: "external cache without addresses",
+			spec: ssdlokiv1.SsdLokiSpec{
+				ChunkStoreConfig: &ssdlokiv1.ChunkStoreConfig{
+					ChunkCacheConfig: &ssdlokiv1.ChunkCacheConfig{
+						Mode: ssdlokiv1.CacheModeExternal,
+					},
+				},
+			},
+			wantField: "spec.chunkStoreConfig.chunkCacheConfig.memcachedClient.addresses",
+		},
+		{
+			desc: "managed cache with addresses",
+			spec: ssdlokiv1.SsdLokiSpec{
+				QueryRange: &ssdlokiv1.QueryRangeConfig{
+					ResultsCache: &ssdlokiv1.ResultsCacheConfig{
+						Cache: &ssdlokiv1.CacheConfig{
+							Mode:            ssdlokiv1.CacheModeManaged,
+							MemcachedClient: &ssdlokiv1.MemcachedClientConfig{Addresses: "memcached:11211"},
+						},
+					},
+				},
+			},
+			wantField: "spec.queryRange.resultsCache.cache.memcachedClient.addresses",
+		},
+		{
+			desc: "duplicate zone topology keys",
+			spec: ssdlokiv1.SsdLokiSpec{
+				Replication: &ssdlokiv1.ReplicationSpec{
+					Zones: []ssdlokiv1.ZoneSpec{
+						{TopologyKey: "topology.kubernetes.io/zone"},
+						{TopologyKey: "topology.kubernetes.io/zone"},
+					},
+				},
+			},
+			wantField: "spec.replication.zones[1].topologyKey",
+		},
+		{
+			desc: "gateway without tenants",
+			spec: ssdlokiv1.SsdLokiSpec{
+				Gateway: &ssdlokiv1.GatewaySpec{Enabled: true},
+			},
+			wantField: "spec.gateway.tenants.authentication",
+		},
+		{
+			desc: "gateway tenant with oidc and mtls",
+			spec: ssdlokiv1.SsdLokiSpec{
+				Gateway: &ssdlokiv1.GatewaySpec{
+					Enabled: true,
+					TLS:     &ssdlokiv1.GatewayTLSSpec{SecretName: "gateway-tls"},
+					Tenants: &ssdlokiv1.TenantsSpec{
+						Authentication: []ssdlokiv1.AuthenticationSpec{
+							{
+								TenantName: "team-a",
+								TenantID:   "team-a",
+								OIDC:       &ssdlokiv1.OIDCSpec{IssuerURL: "https://dex.example.com"},
+								MTLS:       &ssdlokiv1.MTLSSpec{CA: &ssdlokiv1.CASpec{CA: "team-a-ca"}},
+							},
+						},
+					},
+				},
+			},
+			wantField: "spec.gateway.tenants.authentication[0].mTLS",
+		},
+		{
+			desc: "gateway mtls tenant without tls",
+			spec: ssdlokiv1.SsdLokiSpec{
+				Gateway: &ssdlokiv1.GatewaySpec{
+					Enabled: true,
+					Tenants: &ssdlokiv1.TenantsSpec{
+						Authentication: []ssdlokiv1.AuthenticationSpec{
+							{
+								TenantName: "team-a",
+								TenantID:   "team-a",
+								MTLS:       &ssdlokiv1.MTLSSpec{CA: &ssdlokiv1.CASpec{CA: "team-a-ca"}},
+							},
+						},
+					},
+				},
+			},
+			wantField: "spec.gateway.tls",
+		},
+		{
+			desc: "gateway role for unknown tenant",
+			spec: ssdlokiv1.SsdLokiSpec{
+				Gateway: &ssdlokiv1.GatewaySpec{
+					Enabled: true,
+					Tenants: &ssdlokiv1.TenantsSpec{
+						Authentication: []ssdlokiv1.AuthenticationSpec{
+							{
+								TenantName: "team-a",
+								TenantID:   "team-a",
+								OIDC:       &ssdlokiv1.OIDCSpec{IssuerURL: "https://dex.example.com"},
+							},
+						},
+						Authorization: &ssdlokiv1.AuthorizationSpec{
+							Roles: []ssdlokiv1.RoleSpec{
+								{Name: "read", Resources: []string{"logs"}, Tenants: []string{"team-b"}},
+							},
+						},
+					},
+				},
+			},
+			wantField: "spec.gateway.tenants.authorization.roles[0].tenants[0]",
+		},
+		{
+			desc: "internal tls without backend certificate",
+			spec: ssdlokiv1.SsdLokiSpec{
+				TLS: &ssdlokiv1.InternalTLSSpec{
+					Enabled: true,
+					CA:      &ssdlokiv1.CASpec{CA: "loki-ca"},
+					Certificates: &ssdlokiv1.ComponentCertificatesSpec{
+						Read:  "loki-read-tls",
+						Write: "loki-write-tls",
+					},
+				},
+			},
+			wantField: "spec.tls.certificates.backend",
+		},
+		{
+			desc: "rules with object storage for the ruler",
+			spec: ssdlokiv1.SsdLokiSpec{
+				Rules: &ssdlokiv1.RulesSpec{Enabled: true},
+				Ruler: &ssdlokiv1.RulerConfig{
+					Storage: &ssdlokiv1.RulerStorageConfig{Type: "s3"},
+				},
+			},
+			wantField: "spec.ruler.storage.type",
+		},
+		{
+			desc: "alertmanager endpoint without scheme",
+			spec: ssdlokiv1.SsdLokiSpec{
+				Ruler: &ssdlokiv1.RulerConfig{
+					AlertManager: &ssdlokiv1.AlertManagerSpec{
+						AlertManagerOverrideSpec: ssdlokiv1.AlertManagerOverrideSpec{
+							Endpoints: []string{"alertmanager:9093"},
+						},
+					},
+				},
+			},
+			wantField: "spec.ruler.alertmanager.endpoints[0]",
+		},
+		{
+			desc: "alertmanager basic auth in tenant override",
+			spec: ssdlokiv1.SsdLokiSpec{
+				Ruler: &ssdlokiv1.RulerConfig{
+					Overrides: map[string]ssdlokiv1.RulerOverridesSpec{
+						"team-a": {
+							AlertManager: &ssdlokiv1.AlertManagerOverrideSpec{
+								Endpoints: []string{"http://alertmanager.team-a.svc:9093"},
+								Client: &ssdlokiv1.AlertManagerClientSpec{
+									BasicAuth: &ssdlokiv1.AlertManagerClientBasicAuthSpec{Username: "loki"},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantField: "spec.ruler.overrides[team-a].alertmanager.client.basicAuth",
+		},
+		{
+			desc: "retention period shorter than a day",
+			spec: ssdlokiv1.SsdLokiSpec{
+				Retention: &ssdlokiv1.RetentionSpec{
+					Enabled: true,
+					RetentionLimitSpec: ssdlokiv1.RetentionLimitSpec{
+						Streams: []ssdlokiv1.RetentionStreamSpec{
+							{Selector: `{namespace="dev"}`, Period: "12h"},
+						},
+					},
+				},
+			},
+			wantField: "spec.retention.streams[0].period",
+		},
+		{
+			desc: "tenant retention period invalid",
+			spec: ssdlokiv1.SsdLokiSpec{
+				Overrides: map[string]ssdlokiv1.PerTenantLimitsConfig{
+					"team-a": {Retention: &ssdlokiv1.RetentionLimitSpec{Period: "30 days"}},
+				},
+			},
+			wantField: "spec.overrides[team-a].retention.period",
+		},
+		{
+			desc: "remote write url without scheme",
+			spec: ssdlokiv1.SsdLokiSpec{
+				Ruler: &ssdlokiv1.RulerConfig{
+					RemoteWrite: &ssdlokiv1.RemoteWriteSpec{
+						Enabled: true,
+						Client: ssdlokiv1.RemoteWriteClientSpec{
+							Name: "prometheus",
+							URL:  "prometheus:9090/api/v1/write",
+						},
+					},
+				},
+			},
+			wantField: "spec.ruler.remoteWrite.client.url",
+		},
+		{
+			desc: "remote write authorization without secret",
+			spec: ssdlokiv1.SsdLokiSpec{
+				Ruler: &ssdlokiv1.RulerConfig{
+					RemoteWrite: &ssdlokiv1.RemoteWriteSpec{
+						Enabled: true,
+						Client: ssdlokiv1.RemoteWriteClientSpec{
+							Name:              "prometheus",
+							URL:               "http://prometheus.monitoring.svc:9090/api/v1/write",
+							AuthorizationType: ssdlokiv1.RemoteWriteAuthBearer,
+						},
+					},
+				},
+			},
+			wantField: "spec.ruler.remoteWrite.client.authorizationSecretName",
+		},
+		{
+			desc: "rules with invalid selector",
+			spec: ssdlokiv1.SsdLokiSpec{
+				Rules: &ssdlokiv1.RulesSpec{
+					Enabled: true,
+					Selector: &metav1.LabelSelector{
+						MatchExpressions: []metav1.LabelSelectorRequirement{
+							{Key: "team", Operator: "Matches"},
+						},
+					},
+				},
+			},
+			wantField: "spec.rules.selector",
+		},
+		{
+			desc: "replication factor larger than write replicas",
+			spec: ssdlokiv1.SsdLokiSpec{
+				Template: &ssdlokiv1.SsdLokiTemplateSpec{
+					Write: &ssdlokiv1.SsdLokiComponentSpec{Replicas: ptr.To(int32(2))},
+				},
+			},
+			wantField: "spec.common.replicationFactor",
+		},
+		{
+			desc: "demo size lowers the replication factor",
+			spec: ssdlokiv1.SsdLokiSpec{
+				Size: ssdlokiv1.SizeDemo,
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.desc, func(t *testing.T) {
+			g := NewWithT(t)
+
+			stack := &ssdlokiv1.SsdLoki{
+				ObjectMeta: metav1.ObjectMeta{Name: "loki", Namespace: "default"},
+				Spec:       tc.spec,
+			}
+
+			v := &SsdLokiValidator{}
+			_, err := v.ValidateCreate(context.Background(), stack)
+			if tc.wantField == "" {
+				g.Expect(err).NotTo(HaveOccurred())
+				return
+			}
+
+			g.Expect(apierrors.IsInvalid(err)).To(BeTrue(), "unexpected error %v", err)
+			statusErr := err.(*apierrors.StatusError)
+			g.Expect(statusErr.ErrStatus.Details.Causes).To(ContainElement(HaveField("Field", tc.wantField)))
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package validation
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ssdlokiv1 "github.com/ssd-loki/loki-operator/api/v1"
+)
+
+var _ = Describe("SsdLoki Webhook", func() {
+	Context("When creating SsdLoki under Validating Webhook", func() {
+		It("Should deny a spec with an unparsable query timeout", func() {
+			stack := &ssdlokiv1.SsdLoki{
+				ObjectMeta: metav1.ObjectMeta{Name: "invalid", Namespace: "default"},
+				Spec: ssdlokiv1.SsdLokiSpec{
+					LimitsConfig: &ssdlokiv1.LimitsConfig{QueryTimeout: "five minutes"},
+				},
+			}
+
+			err := k8sClient.Create(ctx, stack)
+			Expect(apierrors.IsInvalid(err) || apierrors.IsForbidden(err)).To(BeTrue(), "unexpected error %v", err)
+			Expect(err.Error()).To(ContainSubstring("spec.limitsConfig.queryTimeout"))
+		})
+
+		It("Should admit a minimal spec", func() {
+			stack := &ssdlokiv1.SsdLoki{
+				ObjectMeta: metav1.ObjectMeta{Name: "valid", Namespace: "default"},
+			}
+
+			Expect(k8sClient.Create(ctx, stack)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, stack)).To(Succeed())
+		})
+	})
+})